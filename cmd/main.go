@@ -1,14 +1,22 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"expvar"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	// the runtime image has no zoneinfo, settings validate time zones
+	_ "time/tzdata"
 
 	"github.com/SaidovZohid/medium_user_service/config"
 	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/blob"
 	grpcPkg "github.com/SaidovZohid/medium_user_service/pkg/grpc_client"
 	"github.com/SaidovZohid/medium_user_service/pkg/logger"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
 	"github.com/SaidovZohid/medium_user_service/service"
 	"github.com/SaidovZohid/medium_user_service/storage"
 
@@ -35,7 +43,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
-	fmt.Println("Configuration: ", cfg)
 	fmt.Println("Connected Succesfully!")
 
 	rdb := redis.NewClient(&redis.Options{
@@ -44,6 +51,13 @@ func main() {
 
 	strg := storage.NewStoragePg(psqlConn)
 	inMemory := storage.NewInMemoryStorage(rdb)
+
+	permissionCache := storage.NewPermissionCache(strg.Permission(), inMemory)
+	if err := permissionCache.Load(); err != nil {
+		log.Printf("failed to load permission cache: %v", err)
+	}
+	go permissionCache.Listen(context.Background())
+	strg = storage.NewCachedStorage(strg, permissionCache)
 	
 	grpcConn, err := grpcPkg.New(cfg)
 	if err != nil {
@@ -52,14 +66,68 @@ func main() {
 
 	logger := logger.New()
 
-	userService := service.NewUserService(strg, inMemory, logger)
-	authService := service.NewAuthService(strg, inMemory, grpcConn, &cfg, logger)
+	keys, err := utils.NewKeySet(&cfg)
+	if err != nil {
+		log.Fatalf("failed to load jwt keys: %v", err)
+	}
+
+	blobStorage := blob.NewLocal(cfg.MediaDir, cfg.MediaURL)
+
+	if cfg.UnsubscribeSecret == "" {
+		logger.Warn("UNSUBSCRIBE_SECRET is not set, emails are sent without unsubscribe links")
+	}
+	notifier := service.NewNotifier(strg, grpcConn, &cfg, logger)
+
+	userService := service.NewUserService(strg, inMemory, grpcConn, &cfg, blobStorage, logger)
+	authService := service.NewAuthService(strg, inMemory, notifier, &cfg, keys, logger)
+	permissionService := service.NewPermissionService(strg, inMemory, &cfg, logger)
+	followService := service.NewFollowService(strg, notifier, logger)
+	blockService := service.NewBlockService(strg, logger)
+
+	go service.NewDeletionOrchestrator(strg, grpcConn, blobStorage, &cfg, logger).Run(context.Background())
+
+	if cfg.HttpPort != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/media/", http.StripPrefix("/media/", http.FileServer(http.Dir(cfg.MediaDir))))
+			mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Cache-Control", "public, max-age=300")
+				if err := json.NewEncoder(w).Encode(keys); err != nil {
+					logger.WithError(err).Error("failed to write jwks")
+				}
+			})
+
+			log.Println("HTTP server started port in: ", cfg.HttpPort)
+			if err := http.ListenAndServe(cfg.HttpPort, mux); err != nil {
+				log.Fatalf("Error while listening http: %v", err)
+			}
+		}()
+	}
+
+	if cfg.DebugHttpPort != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/debug/vars", expvar.Handler())
+
+			log.Println("Debug HTTP server started port in: ", cfg.DebugHttpPort)
+			if err := http.ListenAndServe(cfg.DebugHttpPort, mux); err != nil {
+				log.Fatalf("Error while listening debug http: %v", err)
+			}
+		}()
+	}
 
 	listen, err := net.Listen("tcp", cfg.GrpcPort) 
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authService.CallerInterceptor()),
+		grpc.StreamInterceptor(authService.CallerStreamInterceptor()),
+	)
 	pb.RegisterUserServiceServer(s, userService)
 	pb.RegisterAuthServiceServer(s, authService)
+	pb.RegisterPermissionServiceServer(s, permissionService)
+	pb.RegisterFollowServiceServer(s, followService)
+	pb.RegisterBlockServiceServer(s, blockService)
 	reflection.Register(s)
 
 	log.Println("gRPC server started port in: ", cfg.GrpcPort)
@@ -1,18 +1,70 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	GrpcPort      string
+	GrpcPort string
+	HttpPort string
+	// DebugHttpPort serves /debug/vars when set, it must not be reachable
+	// from outside
+	DebugHttpPort string
 	Postgres      PostgresConfig
 	Authorization string
 	Redis         Redis
 
+	AccessTokenDuration  time.Duration
+	RefreshTokenDuration time.Duration
+
+	JwtSigningKeyFile       string
+	JwtVerificationKeyFiles []string
+	// TrustedProxies are the ip addresses or CIDR ranges of the proxies
+	// whose x-forwarded-for metadata is trusted
+	TrustedProxies []string
+
 	NotificationServiceHost     string
 	NotificationServiceGrpcPort string
+	// NotificationFakeSMS logs text messages instead of sending them
+	NotificationFakeSMS bool
+
+	PostServiceHost     string
+	PostServiceGrpcPort string
+
+	DefaultPhoneCountryCode string
+
+	// MagicLinkURL is the page of the web client the token of a magic link is appended to
+	MagicLinkURL string
+	// UnsubscribeURL is the page of the web client the unsubscribe token of an email is appended to
+	UnsubscribeURL string
+	// UnsubscribeSecret signs the unsubscribe tokens, emails have no
+	// unsubscribe link while it is empty
+	UnsubscribeSecret string
+
+	OAuthGoogle OAuthProvider
+	OAuthGithub OAuthProvider
+	OAuthOIDC   OAuthProvider
+
+	// MediaDir keeps uploaded files, they are served by the http server at MediaURL
+	MediaDir string
+	MediaURL string
+	// AvatarMaxSize is the largest avatar upload in bytes
+	AvatarMaxSize int64
+
+	// AccountRestoreWindow is how long a deleted account can be restored,
+	// deleted accounts are erased in the background every AccountPurgeInterval
+	AccountRestoreWindow time.Duration
+	AccountPurgeInterval time.Duration
+}
+
+type OAuthProvider struct {
+	ClientID     string
+	ClientSecret string
+	Issuer       string
 }
 
 type PostgresConfig struct {
@@ -33,8 +85,19 @@ func Load(path string) Config {
 	conf := viper.New()
 	conf.AutomaticEnv()
 
+	conf.SetDefault("ACCESS_TOKEN_DURATION", 15*time.Minute)
+	conf.SetDefault("REFRESH_TOKEN_DURATION", 30*24*time.Hour)
+	conf.SetDefault("DEFAULT_PHONE_COUNTRY_CODE", "998")
+	conf.SetDefault("MEDIA_DIR", "./media")
+	conf.SetDefault("MEDIA_URL", "/media/")
+	conf.SetDefault("AVATAR_MAX_SIZE", 5<<20)
+	conf.SetDefault("ACCOUNT_RESTORE_WINDOW", 30*24*time.Hour)
+	conf.SetDefault("ACCOUNT_PURGE_INTERVAL", time.Minute)
+
 	cfg := Config{
-		GrpcPort: conf.GetString("USER_SERVICE_GRPC_PORT"),
+		GrpcPort:      conf.GetString("USER_SERVICE_GRPC_PORT"),
+		HttpPort:      conf.GetString("USER_SERVICE_HTTP_PORT"),
+		DebugHttpPort: conf.GetString("USER_SERVICE_DEBUG_HTTP_PORT"),
 		Postgres: PostgresConfig{
 			Host:     conf.GetString("POSTGRES_HOST"),
 			Port:     conf.GetString("POSTGRES_PORT"),
@@ -46,8 +109,50 @@ func Load(path string) Config {
 		Redis: Redis{
 			Addr: conf.GetString("REDIS_ADDR"),
 		},
+		AccessTokenDuration:         conf.GetDuration("ACCESS_TOKEN_DURATION"),
+		RefreshTokenDuration:        conf.GetDuration("REFRESH_TOKEN_DURATION"),
+		JwtSigningKeyFile:           conf.GetString("JWT_SIGNING_KEY_FILE"),
+		JwtVerificationKeyFiles:     splitList(conf.GetString("JWT_VERIFICATION_KEY_FILES")),
+		TrustedProxies:              splitList(conf.GetString("TRUSTED_PROXIES")),
 		NotificationServiceHost:     conf.GetString("NOTIFICATION_SERVICE_HOST"),
 		NotificationServiceGrpcPort: conf.GetString("NOTIFICATION_SERVICE_USER_SERVICE_GRPC_PORT"),
+		NotificationFakeSMS:         conf.GetBool("NOTIFICATION_FAKE_SMS"),
+		PostServiceHost:             conf.GetString("POST_SERVICE_HOST"),
+		PostServiceGrpcPort:         conf.GetString("POST_SERVICE_GRPC_PORT"),
+		DefaultPhoneCountryCode:     conf.GetString("DEFAULT_PHONE_COUNTRY_CODE"),
+		MagicLinkURL:                conf.GetString("MAGIC_LINK_URL"),
+		UnsubscribeURL:              conf.GetString("UNSUBSCRIBE_URL"),
+		UnsubscribeSecret:           conf.GetString("UNSUBSCRIBE_SECRET"),
+		OAuthGoogle: OAuthProvider{
+			ClientID:     conf.GetString("OAUTH_GOOGLE_CLIENT_ID"),
+			ClientSecret: conf.GetString("OAUTH_GOOGLE_CLIENT_SECRET"),
+		},
+		OAuthGithub: OAuthProvider{
+			ClientID:     conf.GetString("OAUTH_GITHUB_CLIENT_ID"),
+			ClientSecret: conf.GetString("OAUTH_GITHUB_CLIENT_SECRET"),
+		},
+		OAuthOIDC: OAuthProvider{
+			ClientID:     conf.GetString("OAUTH_OIDC_CLIENT_ID"),
+			ClientSecret: conf.GetString("OAUTH_OIDC_CLIENT_SECRET"),
+			Issuer:       conf.GetString("OAUTH_OIDC_ISSUER"),
+		},
+		MediaDir:      conf.GetString("MEDIA_DIR"),
+		MediaURL:      conf.GetString("MEDIA_URL"),
+		AvatarMaxSize: conf.GetInt64("AVATAR_MAX_SIZE"),
+
+		AccountRestoreWindow: conf.GetDuration("ACCOUNT_RESTORE_WINDOW"),
+		AccountPurgeInterval: conf.GetDuration("ACCOUNT_PURGE_INTERVAL"),
 	}
 	return cfg
 }
+
+// splitList parses a comma separated env value
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
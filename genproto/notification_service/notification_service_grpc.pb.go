@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NotificationServiceClient interface {
 	SendEmail(ctx context.Context, in *SendEmailRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SendSMS(ctx context.Context, in *SendSMSRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type notificationServiceClient struct {
@@ -43,11 +44,21 @@ func (c *notificationServiceClient) SendEmail(ctx context.Context, in *SendEmail
 	return out, nil
 }
 
+func (c *notificationServiceClient) SendSMS(ctx context.Context, in *SendSMSRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.NotificationService/SendSMS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility
 type NotificationServiceServer interface {
 	SendEmail(context.Context, *SendEmailRequest) (*empty.Empty, error)
+	SendSMS(context.Context, *SendSMSRequest) (*empty.Empty, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -58,6 +69,9 @@ type UnimplementedNotificationServiceServer struct {
 func (UnimplementedNotificationServiceServer) SendEmail(context.Context, *SendEmailRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendEmail not implemented")
 }
+func (UnimplementedNotificationServiceServer) SendSMS(context.Context, *SendSMSRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSMS not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 
 // UnsafeNotificationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -89,6 +103,24 @@ func _NotificationService_SendEmail_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_SendSMS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendSMSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).SendSMS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.NotificationService/SendSMS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).SendSMS(ctx, req.(*SendSMSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -100,6 +132,10 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendEmail",
 			Handler:    _NotificationService_SendEmail_Handler,
 		},
+		{
+			MethodName: "SendSMS",
+			Handler:    _NotificationService_SendSMS_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification_service.proto",
@@ -25,9 +25,10 @@ var File_user_service_proto protoreflect.FileDescriptor
 var file_user_service_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0a,
-	0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xd2, 0x02, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xfb, 0x08, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72,
 	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74,
 	0x65, 0x12, 0x0e, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65,
 	0x72, 0x1a, 0x0e, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65,
@@ -48,37 +49,121 @@ var file_user_service_proto_rawDesc = []byte{
 	0x3b, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x2e,
 	0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x45, 0x6d,
 	0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x67, 0x65, 0x6e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15,
-	0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x21, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x51,
+	0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x1d,
+	0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41,
+	0x76, 0x61, 0x74, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28,
+	0x01, 0x12, 0x51, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x79, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x1d, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4d, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x4d, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x44, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x13, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x65, 0x6e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x13, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x65, 0x6e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x00,
+	0x12, 0x56, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x13,
+	0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x00, 0x12, 0x74, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x2e, 0x67, 0x65, 0x6e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x65, 0x6e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x00, 0x12, 0x45,
+	0x0a, 0x0b, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1c, 0x2e,
+	0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_user_service_proto_goTypes = []interface{}{
-	(*User)(nil),                // 0: genproto.User
-	(*IdRequest)(nil),           // 1: genproto.IdRequest
-	(*GetAllUsersRequest)(nil),  // 2: genproto.GetAllUsersRequest
-	(*GetByEmailRequest)(nil),   // 3: genproto.GetByEmailRequest
-	(*GetAllUsersResponse)(nil), // 4: genproto.GetAllUsersResponse
-	(*empty.Empty)(nil),         // 5: google.protobuf.Empty
+	(*User)(nil),                                 // 0: genproto.User
+	(*IdRequest)(nil),                            // 1: genproto.IdRequest
+	(*GetAllUsersRequest)(nil),                   // 2: genproto.GetAllUsersRequest
+	(*GetByEmailRequest)(nil),                    // 3: genproto.GetByEmailRequest
+	(*GetPublicProfileRequest)(nil),              // 4: genproto.GetPublicProfileRequest
+	(*UploadAvatarRequest)(nil),                  // 5: genproto.UploadAvatarRequest
+	(*ExportMyDataRequest)(nil),                  // 6: genproto.ExportMyDataRequest
+	(*DeleteAccountRequest)(nil),                 // 7: genproto.DeleteAccountRequest
+	(*UpdateSettingsRequest)(nil),                // 8: genproto.UpdateSettingsRequest
+	(*UpdateNotificationPreferencesRequest)(nil), // 9: genproto.UpdateNotificationPreferencesRequest
+	(*UnsubscribeRequest)(nil),                   // 10: genproto.UnsubscribeRequest
+	(*GetAllUsersResponse)(nil),                  // 11: genproto.GetAllUsersResponse
+	(*empty.Empty)(nil),                          // 12: google.protobuf.Empty
+	(*PublicProfile)(nil),                        // 13: genproto.PublicProfile
+	(*UploadAvatarResponse)(nil),                 // 14: genproto.UploadAvatarResponse
+	(*ExportMyDataResponse)(nil),                 // 15: genproto.ExportMyDataResponse
+	(*DeletionStatus)(nil),                       // 16: genproto.DeletionStatus
+	(*UserSettings)(nil),                         // 17: genproto.UserSettings
+	(*NotificationPreferences)(nil),              // 18: genproto.NotificationPreferences
 }
 var file_user_service_proto_depIdxs = []int32{
-	0, // 0: genproto.UserService.Create:input_type -> genproto.User
-	1, // 1: genproto.UserService.Get:input_type -> genproto.IdRequest
-	2, // 2: genproto.UserService.GetAll:input_type -> genproto.GetAllUsersRequest
-	0, // 3: genproto.UserService.Update:input_type -> genproto.User
-	1, // 4: genproto.UserService.Delete:input_type -> genproto.IdRequest
-	3, // 5: genproto.UserService.GetByEmail:input_type -> genproto.GetByEmailRequest
-	0, // 6: genproto.UserService.Create:output_type -> genproto.User
-	0, // 7: genproto.UserService.Get:output_type -> genproto.User
-	4, // 8: genproto.UserService.GetAll:output_type -> genproto.GetAllUsersResponse
-	0, // 9: genproto.UserService.Update:output_type -> genproto.User
-	5, // 10: genproto.UserService.Delete:output_type -> google.protobuf.Empty
-	0, // 11: genproto.UserService.GetByEmail:output_type -> genproto.User
-	6, // [6:12] is the sub-list for method output_type
-	0, // [0:6] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: genproto.UserService.Create:input_type -> genproto.User
+	1,  // 1: genproto.UserService.Get:input_type -> genproto.IdRequest
+	2,  // 2: genproto.UserService.GetAll:input_type -> genproto.GetAllUsersRequest
+	0,  // 3: genproto.UserService.Update:input_type -> genproto.User
+	1,  // 4: genproto.UserService.Delete:input_type -> genproto.IdRequest
+	3,  // 5: genproto.UserService.GetByEmail:input_type -> genproto.GetByEmailRequest
+	4,  // 6: genproto.UserService.GetPublicProfile:input_type -> genproto.GetPublicProfileRequest
+	5,  // 7: genproto.UserService.UploadAvatar:input_type -> genproto.UploadAvatarRequest
+	6,  // 8: genproto.UserService.ExportMyData:input_type -> genproto.ExportMyDataRequest
+	7,  // 9: genproto.UserService.DeleteAccount:input_type -> genproto.DeleteAccountRequest
+	1,  // 10: genproto.UserService.GetDeletionStatus:input_type -> genproto.IdRequest
+	1,  // 11: genproto.UserService.GetSettings:input_type -> genproto.IdRequest
+	8,  // 12: genproto.UserService.UpdateSettings:input_type -> genproto.UpdateSettingsRequest
+	1,  // 13: genproto.UserService.GetNotificationPreferences:input_type -> genproto.IdRequest
+	9,  // 14: genproto.UserService.UpdateNotificationPreferences:input_type -> genproto.UpdateNotificationPreferencesRequest
+	10, // 15: genproto.UserService.Unsubscribe:input_type -> genproto.UnsubscribeRequest
+	0,  // 16: genproto.UserService.Create:output_type -> genproto.User
+	0,  // 17: genproto.UserService.Get:output_type -> genproto.User
+	11, // 18: genproto.UserService.GetAll:output_type -> genproto.GetAllUsersResponse
+	0,  // 19: genproto.UserService.Update:output_type -> genproto.User
+	12, // 20: genproto.UserService.Delete:output_type -> google.protobuf.Empty
+	0,  // 21: genproto.UserService.GetByEmail:output_type -> genproto.User
+	13, // 22: genproto.UserService.GetPublicProfile:output_type -> genproto.PublicProfile
+	14, // 23: genproto.UserService.UploadAvatar:output_type -> genproto.UploadAvatarResponse
+	15, // 24: genproto.UserService.ExportMyData:output_type -> genproto.ExportMyDataResponse
+	12, // 25: genproto.UserService.DeleteAccount:output_type -> google.protobuf.Empty
+	16, // 26: genproto.UserService.GetDeletionStatus:output_type -> genproto.DeletionStatus
+	17, // 27: genproto.UserService.GetSettings:output_type -> genproto.UserSettings
+	17, // 28: genproto.UserService.UpdateSettings:output_type -> genproto.UserSettings
+	18, // 29: genproto.UserService.GetNotificationPreferences:output_type -> genproto.NotificationPreferences
+	18, // 30: genproto.UserService.UpdateNotificationPreferences:output_type -> genproto.NotificationPreferences
+	12, // 31: genproto.UserService.Unsubscribe:output_type -> google.protobuf.Empty
+	16, // [16:32] is the sub-list for method output_type
+	0,  // [0:16] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_user_service_proto_init() }
@@ -87,6 +172,7 @@ func file_user_service_proto_init() {
 		return
 	}
 	file_user_proto_init()
+	file_settings_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -0,0 +1 @@
+ALTER TABLE "permissions" DROP COLUMN IF EXISTS "scope";
\ No newline at end of file
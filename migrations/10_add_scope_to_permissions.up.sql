@@ -0,0 +1,5 @@
+ALTER TABLE "permissions" ADD COLUMN IF NOT EXISTS "scope" VARCHAR(10) NOT NULL DEFAULT 'any' CHECK ("scope" IN('any', 'own'));
+
+-- regular users may change only what they own
+UPDATE permissions SET scope = 'own'
+WHERE user_type = 'user' AND resource IN('users', 'posts', 'comments') AND action IN('update', 'delete');
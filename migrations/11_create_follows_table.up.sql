@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "follows" (
+    "follower_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "following_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("follower_id", "following_id"),
+    CHECK ("follower_id" <> "following_id")
+);
+
+CREATE INDEX IF NOT EXISTS "follows_following_id_idx" ON "follows"("following_id");
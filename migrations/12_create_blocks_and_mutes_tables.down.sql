@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "mutes";
+DROP TABLE IF EXISTS "blocks";
\ No newline at end of file
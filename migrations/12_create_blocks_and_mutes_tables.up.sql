@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "blocks" (
+    "blocker_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "blocked_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("blocker_id", "blocked_id"),
+    CHECK ("blocker_id" <> "blocked_id")
+);
+
+CREATE INDEX IF NOT EXISTS "blocks_blocked_id_idx" ON "blocks"("blocked_id");
+
+CREATE TABLE IF NOT EXISTS "mutes" (
+    "muter_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "muted_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("muter_id", "muted_id"),
+    CHECK ("muter_id" <> "muted_id")
+);
@@ -0,0 +1,6 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "pronouns";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "location";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "social_links";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "website";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "headline";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "bio";
\ No newline at end of file
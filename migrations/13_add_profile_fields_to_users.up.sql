@@ -0,0 +1,6 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "bio" VARCHAR(500);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "headline" VARCHAR(100);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "website" VARCHAR(255);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "social_links" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "location" VARCHAR(100);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "pronouns" VARCHAR(30);
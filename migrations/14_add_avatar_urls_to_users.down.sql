@@ -0,0 +1 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "avatar_urls";
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "purged_at" TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS "users_deleted_at_idx" ON "users"("deleted_at") WHERE "deleted_at" IS NOT NULL AND "purged_at" IS NULL;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "account_deletions" (
+    "user_id" INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "completed_at" TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS "account_deletion_steps" (
+    "user_id" INTEGER NOT NULL REFERENCES account_deletions(user_id) ON DELETE CASCADE,
+    "step" VARCHAR(50) NOT NULL,
+    "position" INTEGER NOT NULL,
+    "status" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN('pending', 'done')),
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "last_error" VARCHAR,
+    "updated_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("user_id", "step")
+);
+
+CREATE INDEX IF NOT EXISTS "account_deletions_pending_idx" ON "account_deletions"("created_at") WHERE "completed_at" IS NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "user_settings" (
+    "user_id" INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "schema_version" INTEGER NOT NULL DEFAULT 1,
+    "language" VARCHAR(35),
+    "timezone" VARCHAR(64),
+    "theme" VARCHAR(10) CHECK ("theme" IN('system', 'light', 'dark')),
+    "default_post_visibility" VARCHAR(20) CHECK ("default_post_visibility" IN('public', 'followers', 'private')),
+    "email_frequency" VARCHAR(20) CHECK ("email_frequency" IN('immediate', 'daily', 'weekly', 'never')),
+    "updated_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+    "user_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "category" VARCHAR(30) NOT NULL CHECK ("category" IN('security', 'social', 'marketing', 'digest')),
+    "channel" VARCHAR(10) NOT NULL CHECK ("channel" IN('email', 'sms')),
+    "enabled" BOOLEAN NOT NULL,
+    "updated_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("user_id", "category", "channel")
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "account_deletions_pending_idx";
+CREATE INDEX IF NOT EXISTS "account_deletions_pending_idx" ON "account_deletions"("created_at") WHERE "completed_at" IS NULL;
+
+ALTER TABLE "account_deletions" DROP COLUMN IF EXISTS "next_attempt_at";
\ No newline at end of file
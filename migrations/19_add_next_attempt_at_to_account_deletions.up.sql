@@ -0,0 +1,4 @@
+ALTER TABLE "account_deletions" ADD COLUMN IF NOT EXISTS "next_attempt_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+DROP INDEX IF EXISTS "account_deletions_pending_idx";
+CREATE INDEX IF NOT EXISTS "account_deletions_pending_idx" ON "account_deletions"("next_attempt_at") WHERE "completed_at" IS NULL;
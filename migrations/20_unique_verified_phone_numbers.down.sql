@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "users_verified_phone_number_key";
+
+ALTER TABLE "users" ADD CONSTRAINT "users_phone_number_key" UNIQUE ("phone_number");
\ No newline at end of file
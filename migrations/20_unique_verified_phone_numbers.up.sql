@@ -0,0 +1,5 @@
+-- Only verified phone numbers are unique, so nobody can hold a number by
+-- saving it without the code sent to it
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_phone_number_key";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "users_verified_phone_number_key" ON "users"("phone_number") WHERE "phone_verified_at" IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Emails that only differ in case belong to the same person. The active and
+-- then the oldest account keeps the email, the others get a placeholder that
+-- can not be logged in with.
+UPDATE "users" SET "email" = 'duplicate-' || "id" || '@duplicate.invalid'
+WHERE "id" IN (
+    SELECT "id" FROM (
+        SELECT "id", row_number() OVER (
+            PARTITION BY lower("email")
+            ORDER BY "deleted_at" IS NOT NULL, "id"
+        ) AS "position"
+        FROM "users"
+    ) AS "ranked"
+    WHERE "position" > 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "users_lower_email_key" ON "users"(lower("email"));
@@ -0,0 +1,3 @@
+DELETE FROM "account_deletion_steps" WHERE "status" = 'skipped';
+ALTER TABLE "account_deletion_steps" DROP CONSTRAINT IF EXISTS "account_deletion_steps_status_check";
+ALTER TABLE "account_deletion_steps" ADD CONSTRAINT "account_deletion_steps_status_check" CHECK ("status" IN('pending', 'done'));
\ No newline at end of file
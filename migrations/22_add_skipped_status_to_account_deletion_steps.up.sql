@@ -0,0 +1,3 @@
+-- Steps the post service can not do yet are recorded as skipped instead of done
+ALTER TABLE "account_deletion_steps" DROP CONSTRAINT IF EXISTS "account_deletion_steps_status_check";
+ALTER TABLE "account_deletion_steps" ADD CONSTRAINT "account_deletion_steps_status_check" CHECK ("status" IN('pending', 'done', 'skipped'));
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "sessions" (
+    "id" UUID PRIMARY KEY,
+    "user_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "token_id" UUID NOT NULL,
+    "user_agent" VARCHAR,
+    "ip_address" VARCHAR(45),
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "revoked_at" TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS "sessions_user_id_idx" ON "sessions"("user_id");
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "user_mfa" (
+    "user_id" INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "secret" VARCHAR NOT NULL,
+    "enabled_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "mfa_recovery_codes" (
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "code_hash" VARCHAR NOT NULL,
+    "used_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "mfa_recovery_codes_user_id_idx" ON "mfa_recovery_codes"("user_id");
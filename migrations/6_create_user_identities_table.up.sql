@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "user_identities" (
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "provider" VARCHAR(30) NOT NULL,
+    "subject" VARCHAR NOT NULL,
+    "email" VARCHAR(50),
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(provider, subject)
+);
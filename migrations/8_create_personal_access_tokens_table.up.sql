@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "personal_access_tokens" (
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "name" VARCHAR(100) NOT NULL,
+    "token_hash" VARCHAR(64) NOT NULL UNIQUE,
+    "prefix" VARCHAR(20) NOT NULL,
+    "scopes" TEXT[] NOT NULL DEFAULT '{}',
+    "expires_at" TIMESTAMP WITH TIME ZONE,
+    "last_used_at" TIMESTAMP WITH TIME ZONE,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    "revoked_at" TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS "personal_access_tokens_user_id_idx" ON "personal_access_tokens"("user_id");
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "roles" (
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL UNIQUE,
+    "description" VARCHAR,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO roles(name, description) VALUES ('superadmin', 'Full access') ON CONFLICT DO NOTHING;
+INSERT INTO roles(name, description) VALUES ('user', 'Default role of registered users') ON CONFLICT DO NOTHING;
+
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_type_check";
+ALTER TABLE "users" ADD CONSTRAINT "users_type_fkey"
+    FOREIGN KEY ("type") REFERENCES roles(name) ON UPDATE CASCADE;
+
+ALTER TABLE "permissions" DROP CONSTRAINT IF EXISTS "permissions_user_type_check";
+ALTER TABLE "permissions" ADD CONSTRAINT "permissions_user_type_fkey"
+    FOREIGN KEY ("user_type") REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE;
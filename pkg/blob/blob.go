@@ -0,0 +1,17 @@
+// Package blob stores uploaded files such as avatars.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+type Storage interface {
+	// Put saves the content under key and returns the public url of it
+	Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+	// Delete removes the blob by the url Put returned
+	Delete(ctx context.Context, url string) error
+}
@@ -0,0 +1,85 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocal stores blobs as files under dir, served by the http server at baseURL
+func NewLocal(dir, baseURL string) Storage {
+	return &localStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/",
+	}
+}
+
+func (l *localStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first so a half written blob is never served.
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", err
+	}
+
+	return l.baseURL + key, nil
+}
+
+func (l *localStorage) Delete(ctx context.Context, url string) error {
+	if !strings.HasPrefix(url, l.baseURL) {
+		return ErrNotFound
+	}
+
+	name, err := l.path(strings.TrimPrefix(url, l.baseURL))
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// path rejects keys that would point outside of the directory
+func (l *localStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,38 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage := NewLocal(dir, "http://localhost:8000/media")
+	ctx := context.Background()
+
+	url, err := storage.Put(ctx, "avatars/1/64.jpg", strings.NewReader("image"), "image/jpeg")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8000/media/avatars/1/64.jpg", url)
+
+	data, err := os.ReadFile(filepath.Join(dir, "avatars", "1", "64.jpg"))
+	require.NoError(t, err)
+	require.Equal(t, "image", string(data))
+
+	require.NoError(t, storage.Delete(ctx, url))
+	require.ErrorIs(t, storage.Delete(ctx, url), ErrNotFound)
+}
+
+func TestLocalStorageRejectsTraversal(t *testing.T) {
+	storage := NewLocal(t.TempDir(), "/media/")
+	ctx := context.Background()
+
+	for _, key := range []string{"", "../secret", "avatars/../../secret", "/etc/passwd", "a//b"} {
+		_, err := storage.Put(ctx, key, strings.NewReader("x"), "text/plain")
+		require.Error(t, err, key)
+	}
+}
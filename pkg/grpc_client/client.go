@@ -5,12 +5,16 @@ import (
 
 	"github.com/SaidovZohid/medium_user_service/config"
 	pbn "github.com/SaidovZohid/medium_user_service/genproto/notification_service"
+	pbp "github.com/SaidovZohid/medium_user_service/genproto/post_service"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type GrpcClientI interface {
 	NotificationService() pbn.NotificationServiceClient
+	PostService() pbp.PostServiceClient
+	CommentService() pbp.CommentServiceClient
+	LikeService() pbp.LikeServiceClient
 }
 
 type GrpcClient struct {
@@ -27,14 +31,42 @@ func New(cfg config.Config) (GrpcClientI, error) {
 		return nil, fmt.Errorf("notification service dial host: %s port %s err: %v", cfg.NotificationServiceHost, cfg.NotificationServiceGrpcPort, err)
 	}
 
+	notificationService := pbn.NewNotificationServiceClient(conNotificationService)
+	if cfg.NotificationFakeSMS {
+		notificationService = NewFakeSMSNotificationClient(notificationService)
+	}
+
+	conPostService, err := grpc.Dial(
+		fmt.Sprintf("%s%s", cfg.PostServiceHost, cfg.PostServiceGrpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("post service dial host: %s port %s err: %v", cfg.PostServiceHost, cfg.PostServiceGrpcPort, err)
+	}
+
 	return &GrpcClient{
 		cfg: cfg,
 		connections: map[string]interface{}{
-			"notification_service": pbn.NewNotificationServiceClient(conNotificationService),
+			"notification_service": notificationService,
+			"post_service":         pbp.NewPostServiceClient(conPostService),
+			"comment_service":      pbp.NewCommentServiceClient(conPostService),
+			"like_service":         pbp.NewLikeServiceClient(conPostService),
 		},
-	}, nil 
+	}, nil
 }
 
 func (g *GrpcClient) NotificationService() pbn.NotificationServiceClient {
 	return g.connections["notification_service"].(pbn.NotificationServiceClient)
 }
+
+func (g *GrpcClient) PostService() pbp.PostServiceClient {
+	return g.connections["post_service"].(pbp.PostServiceClient)
+}
+
+func (g *GrpcClient) CommentService() pbp.CommentServiceClient {
+	return g.connections["comment_service"].(pbp.CommentServiceClient)
+}
+
+func (g *GrpcClient) LikeService() pbp.LikeServiceClient {
+	return g.connections["like_service"].(pbp.LikeServiceClient)
+}
@@ -0,0 +1,27 @@
+package grpc_client
+
+import (
+	"context"
+	"log"
+
+	pbn "github.com/SaidovZohid/medium_user_service/genproto/notification_service"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeSMSNotificationClient sends emails through the notification service
+// but only logs text messages, for local development without an SMS gateway.
+type fakeSMSNotificationClient struct {
+	pbn.NotificationServiceClient
+}
+
+func NewFakeSMSNotificationClient(client pbn.NotificationServiceClient) pbn.NotificationServiceClient {
+	return &fakeSMSNotificationClient{
+		NotificationServiceClient: client,
+	}
+}
+
+func (f *fakeSMSNotificationClient) SendSMS(ctx context.Context, in *pbn.SendSMSRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	log.Printf("fake sms to %s (%s): %s", in.To, in.Type, in.Text)
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,78 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	githubTokenURL  = "https://github.com/login/oauth/access_token"
+	githubUserURL   = "https://api.github.com/user"
+	githubEmailsURL = "https://api.github.com/user/emails"
+)
+
+// GithubProvider logs users in with GitHub, which speaks plain OAuth2 and
+// has no id token, so the user is read from the REST API instead.
+type GithubProvider struct {
+	clientID     string
+	clientSecret string
+	client       *http.Client
+}
+
+func NewGithubProvider(clientID, clientSecret string, client *http.Client) *GithubProvider {
+	return &GithubProvider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       client,
+	}
+}
+
+func (p *GithubProvider) Exchange(ctx context.Context, code, redirectURI, codeVerifier string) (*Identity, error) {
+	token, err := exchangeCode(ctx, p.client, githubTokenURL, p.clientID, p.clientSecret, code, redirectURI, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("github did not return an access token")
+	}
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getJSON(ctx, p.client, githubUserURL, token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, p.client, githubEmailsURL, token.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider: ProviderGithub,
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Picture:  user.AvatarURL,
+	}
+	identity.FirstName, identity.LastName = splitName(user.Name)
+	if identity.FirstName == "" {
+		identity.FirstName = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = strings.ToLower(e.Email)
+			identity.EmailVerified = e.Verified
+		}
+	}
+
+	return identity, nil
+}
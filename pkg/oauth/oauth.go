@@ -0,0 +1,133 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+)
+
+const (
+	ProviderGoogle = "google"
+	ProviderGithub = "github"
+	ProviderOIDC   = "oidc"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown oauth provider")
+	ErrInvalidIDToken  = errors.New("invalid id token")
+)
+
+// Identity is the user as the provider knows it
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Picture       string
+}
+
+type Provider interface {
+	// Exchange trades the authorization code for the identity of the user
+	Exchange(ctx context.Context, code, redirectURI, codeVerifier string) (*Identity, error)
+}
+
+// NewProviders returns the providers that have a client id configured
+func NewProviders(cfg *config.Config) map[string]Provider {
+	client := &http.Client{Timeout: 10 * time.Second}
+	providers := make(map[string]Provider)
+
+	if cfg.OAuthGoogle.ClientID != "" {
+		issuer := cfg.OAuthGoogle.Issuer
+		if issuer == "" {
+			issuer = "https://accounts.google.com"
+		}
+		providers[ProviderGoogle] = NewOIDCProvider(ProviderGoogle, issuer, cfg.OAuthGoogle.ClientID, cfg.OAuthGoogle.ClientSecret, client)
+	}
+	if cfg.OAuthGithub.ClientID != "" {
+		providers[ProviderGithub] = NewGithubProvider(cfg.OAuthGithub.ClientID, cfg.OAuthGithub.ClientSecret, client)
+	}
+	if cfg.OAuthOIDC.ClientID != "" {
+		providers[ProviderOIDC] = NewOIDCProvider(ProviderOIDC, cfg.OAuthOIDC.Issuer, cfg.OAuthOIDC.ClientID, cfg.OAuthOIDC.ClientSecret, client)
+	}
+
+	return providers
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchangeCode performs the authorization code grant against the token endpoint
+func exchangeCode(ctx context.Context, client *http.Client, endpoint, clientID, clientSecret, code, redirectURI, codeVerifier string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	if redirectURI != "" {
+		form.Set("redirect_uri", redirectURI)
+	}
+	if codeVerifier != "" {
+		form.Set("code_verifier", codeVerifier)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var res tokenResponse
+	if err := doJSON(client, req, &res); err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("oauth token exchange failed: %s %s", res.Error, res.ErrorDescription)
+	}
+	return &res, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, endpoint, accessToken string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	return doJSON(client, req, v)
+}
+
+func doJSON(client *http.Client, req *http.Request, v interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s: unexpected status %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,272 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// discoveryTTL is how long the discovery document and the provider keys are cached
+const discoveryTTL = time.Hour
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type idTokenClaims struct {
+	Issuer        string      `json:"iss"`
+	Subject       string      `json:"sub"`
+	Audience      audience    `json:"aud"`
+	ExpiresAt     int64       `json:"exp"`
+	IssuedAt      int64       `json:"iat"`
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	GivenName     string      `json:"given_name"`
+	FamilyName    string      `json:"family_name"`
+	Name          string      `json:"name"`
+	Picture       string      `json:"picture"`
+}
+
+// Valid is called by the jwt parser, the other claims are checked in verifyIDToken
+func (c *idTokenClaims) Valid() error {
+	now := time.Now().Unix()
+	if c.ExpiresAt == 0 || now > c.ExpiresAt {
+		return errors.New("token is expired")
+	}
+	if c.IssuedAt > now+60 {
+		return errors.New("token used before issued")
+	}
+	return nil
+}
+
+// audience is either a single string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// OIDCProvider logs users in with any OpenID Connect provider, the
+// endpoints are found through the issuer's discovery document.
+type OIDCProvider struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+
+	mu        sync.Mutex
+	config    *discovery
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func NewOIDCProvider(name, issuer, clientID, clientSecret string, client *http.Client) *OIDCProvider {
+	return &OIDCProvider{
+		name:         name,
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       client,
+	}
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, redirectURI, codeVerifier string) (*Identity, error) {
+	config, err := p.discover(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := exchangeCode(ctx, p.client, config.TokenEndpoint, p.clientID, p.clientSecret, code, redirectURI, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: provider did not return an id token", ErrInvalidIDToken)
+	}
+
+	claims, err := p.verifyIDToken(ctx, token.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider:      p.name,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+		FirstName:     claims.GivenName,
+		LastName:      claims.FamilyName,
+		Picture:       claims.Picture,
+	}
+	if identity.FirstName == "" && identity.LastName == "" {
+		identity.FirstName, identity.LastName = splitName(claims.Name)
+	}
+
+	return identity, nil
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, rawToken string) (*idTokenClaims, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(rawToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Issuer != p.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %s", ErrInvalidIDToken, claims.Issuer)
+	}
+	if !claims.Audience.contains(p.clientID) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidIDToken)
+	}
+
+	return &claims, nil
+}
+
+// key returns the provider key with the kid. The keys are fetched again
+// when the kid is unknown, because providers rotate their keys.
+func (p *OIDCProvider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	fresh := time.Since(p.fetchedAt) < time.Minute
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if fresh {
+		return nil, errors.New("unknown signing key")
+	}
+
+	if _, err := p.discover(ctx, true); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errors.New("unknown signing key")
+}
+
+func (p *OIDCProvider) discover(ctx context.Context, force bool) (*discovery, error) {
+	p.mu.Lock()
+	if !force && p.config != nil && time.Since(p.fetchedAt) < discoveryTTL {
+		config := p.config
+		p.mu.Unlock()
+		return config, nil
+	}
+	p.mu.Unlock()
+
+	var config discovery
+	if err := getJSON(ctx, p.client, p.issuer+"/.well-known/openid-configuration", "", &config); err != nil {
+		return nil, fmt.Errorf("failed to get openid configuration: %w", err)
+	}
+	if strings.TrimSuffix(config.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("openid configuration issuer %s does not match %s", config.Issuer, p.issuer)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(ctx, p.client, config.JwksURI, "", &set); err != nil {
+		return nil, fmt.Errorf("failed to get provider keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		key, err := rsaKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keys[k.Kid] = key
+	}
+
+	p.mu.Lock()
+	p.config = &config
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	return &config, nil
+}
+
+func rsaKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s: %w", k.Kid, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s: %w", k.Kid, err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func splitName(name string) (string, string) {
+	parts := strings.Fields(name)
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return parts[0], ""
+	default:
+		return parts[0], strings.Join(parts[1:], " ")
+	}
+}
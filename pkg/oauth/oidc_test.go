@@ -0,0 +1,121 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOIDCServer is a minimal OpenID provider that answers the code
+// "valid-code" with an id token carrying claims.
+type fakeOIDCServer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+}
+
+func newFakeOIDCServer(t *testing.T) *fakeOIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	f := &fakeOIDCServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         f.URL,
+			"token_endpoint": f.URL + "/token",
+			"jwks_uri":       f.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("code") != "valid-code" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, f.claims)
+		token.Header["kid"] = "test-key"
+		idToken, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"id_token":     idToken,
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+
+	f.claims = jwt.MapClaims{
+		"iss":            f.URL,
+		"sub":            "1234567890",
+		"aud":            "client",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"email":          "Reader@Example.com",
+		"email_verified": true,
+		"name":           "Zohid Saidov",
+	}
+	return f
+}
+
+func TestOIDCExchange(t *testing.T) {
+	server := newFakeOIDCServer(t)
+	provider := NewOIDCProvider(ProviderOIDC, server.URL, "client", "secret", server.Client())
+
+	identity, err := provider.Exchange(context.Background(), "valid-code", "http://localhost/callback", "")
+	require.NoError(t, err)
+	require.Equal(t, &Identity{
+		Provider:      ProviderOIDC,
+		Subject:       "1234567890",
+		Email:         "reader@example.com",
+		EmailVerified: true,
+		FirstName:     "Zohid",
+		LastName:      "Saidov",
+	}, identity)
+
+	_, err = provider.Exchange(context.Background(), "wrong-code", "http://localhost/callback", "")
+	require.Error(t, err)
+}
+
+func TestOIDCRejectsInvalidIDToken(t *testing.T) {
+	cases := map[string]jwt.MapClaims{
+		"wrong audience": {"aud": "other-client"},
+		"wrong issuer":   {"iss": "https://evil.example.com"},
+		"expired":        {"exp": time.Now().Add(-time.Hour).Unix()},
+	}
+	for name, override := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newFakeOIDCServer(t)
+			for k, v := range override {
+				server.claims[k] = v
+			}
+			provider := NewOIDCProvider(ProviderOIDC, server.URL, "client", "secret", server.Client())
+
+			_, err := provider.Exchange(context.Background(), "valid-code", "", "")
+			require.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package policy decides whether a subject may perform an action on a
+// resource, taking ownership of the resource into account.
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Scope string
+
+const (
+	// ScopeAny allows the action on every resource
+	ScopeAny Scope = "any"
+	// ScopeOwn allows the action only on resources owned by the subject
+	ScopeOwn Scope = "own"
+)
+
+// ParseScope treats an empty scope as ScopeAny
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case "", ScopeAny:
+		return ScopeAny, nil
+	case ScopeOwn:
+		return ScopeOwn, nil
+	}
+	return "", fmt.Errorf("unknown scope %q", s)
+}
+
+type Rule struct {
+	Resource string
+	Action   string
+	Scope    Scope
+}
+
+// ParseRule parses rules in the resource:action[:scope] form, e.g. users:update:own
+func ParseRule(s string) (Rule, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return Rule{}, fmt.Errorf("invalid rule %q", s)
+	}
+
+	rule := Rule{
+		Resource: parts[0],
+		Action:   parts[1],
+		Scope:    ScopeAny,
+	}
+	if len(parts) == 3 {
+		scope, err := ParseScope(parts[2])
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Scope = scope
+	}
+
+	return rule, nil
+}
+
+func (r Rule) String() string {
+	return r.Resource + ":" + r.Action + ":" + string(r.Scope)
+}
+
+type Request struct {
+	SubjectID int64
+	Resource  string
+	Action    string
+	// OwnerID is the owner of the resource, zero if it is not known
+	OwnerID int64
+}
+
+// Evaluate allows the request if any of the rules matches it. A rule with
+// ScopeOwn matches only when the owner is known to be the subject.
+func Evaluate(rules []Rule, req Request) bool {
+	for _, rule := range rules {
+		if rule.Resource != req.Resource || rule.Action != req.Action {
+			continue
+		}
+		switch rule.Scope {
+		case ScopeAny, "":
+			return true
+		case ScopeOwn:
+			if req.OwnerID != 0 && req.OwnerID == req.SubjectID {
+				return true
+			}
+		}
+	}
+	return false
+}
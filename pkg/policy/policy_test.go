@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("users:update:own")
+	require.NoError(t, err)
+	require.Equal(t, Rule{Resource: "users", Action: "update", Scope: ScopeOwn}, rule)
+
+	rule, err = ParseRule("posts:create")
+	require.NoError(t, err)
+	require.Equal(t, ScopeAny, rule.Scope)
+	require.Equal(t, "posts:create:any", rule.String())
+
+	for _, s := range []string{"", "users", "users::own", "users:update:mine", "a:b:c:d"} {
+		_, err = ParseRule(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []Rule{
+		{Resource: "users", Action: "update", Scope: ScopeOwn},
+		{Resource: "users", Action: "get", Scope: ScopeAny},
+	}
+
+	testCases := []struct {
+		name string
+		req  Request
+		want bool
+	}{
+		{"any scope", Request{SubjectID: 1, Resource: "users", Action: "get", OwnerID: 2}, true},
+		{"own resource", Request{SubjectID: 1, Resource: "users", Action: "update", OwnerID: 1}, true},
+		{"other's resource", Request{SubjectID: 1, Resource: "users", Action: "update", OwnerID: 2}, false},
+		{"unknown owner", Request{SubjectID: 1, Resource: "users", Action: "update"}, false},
+		{"no rule", Request{SubjectID: 1, Resource: "users", Action: "delete", OwnerID: 1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Evaluate(rules, tc.req))
+		})
+	}
+
+	// any wins over own for the same action
+	rules = append(rules, Rule{Resource: "users", Action: "update", Scope: ScopeAny})
+	require.True(t, Evaluate(rules, Request{SubjectID: 1, Resource: "users", Action: "update", OwnerID: 2}))
+}
@@ -0,0 +1,8 @@
+package utils
+
+import "strings"
+
+// NormalizeEmail lowercases the email, emails are unique regardless of case
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,11 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	require.Equal(t, "foo@example.com", NormalizeEmail(" Foo@Example.COM "))
+}
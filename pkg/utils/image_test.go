@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 10, B: 10, A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDecodeImage(t *testing.T) {
+	img, err := DecodeImage(testPNG(t, 30, 20), 1000)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 30, 20), img.Bounds())
+
+	_, err = DecodeImage(testPNG(t, 40, 30), 1000)
+	require.ErrorIs(t, err, ErrImageTooLarge)
+
+	_, err = DecodeImage([]byte("<html></html>"), 1000)
+	require.ErrorIs(t, err, ErrUnsupportedImage)
+}
+
+func TestResizeSquare(t *testing.T) {
+	img, err := DecodeImage(testPNG(t, 300, 200), 1<<20)
+	require.NoError(t, err)
+
+	for _, size := range []int{64, 256, 512} {
+		resized := ResizeSquare(img, size)
+		require.Equal(t, image.Rect(0, 0, size, size), resized.Bounds())
+
+		c := resized.RGBAAt(size/2, size/2)
+		require.Equal(t, color.RGBA{R: 200, G: 10, B: 10, A: 0xff}, c)
+	}
+
+	data, err := EncodeJPEG(ResizeSquare(img, 64))
+	require.NoError(t, err)
+	_, err = DecodeImage(data, 1<<20)
+	require.NoError(t, err)
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	"github.com/golang-jwt/jwt"
+)
+
+// JSONWebKey is the public part of a verification key as described in RFC 7517
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+	jwk    JSONWebKey
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are
+// still accepted from. Retired signing keys stay in the verification keys
+// until the tokens signed with them have expired.
+type KeySet struct {
+	signingKID    string
+	signingMethod jwt.SigningMethod
+	signingKey    crypto.PrivateKey
+
+	verification map[string]*verificationKey
+	order        []string
+
+	// secret is the shared HS256 secret used when no signing key is
+	// configured. Tokens without a kid are verified with it, with a signing
+	// key it is nil and such tokens are rejected.
+	secret []byte
+}
+
+// NewKeySet loads the signing key and the additional verification keys
+// from the PEM files set in the config. Without a signing key file tokens
+// are signed with HS256 and the shared secret.
+func NewKeySet(cfg *config.Config) (*KeySet, error) {
+	ks := &KeySet{
+		verification: make(map[string]*verificationKey),
+	}
+
+	// The secret is only trusted while no signing key is configured, else
+	// anyone knowing it could mint tokens that bypass the key pair
+	if cfg.JwtSigningKeyFile == "" {
+		if cfg.Authorization == "" {
+			return nil, errors.New("neither a jwt signing key file nor a secret key is configured")
+		}
+		ks.secret = []byte(cfg.Authorization)
+		ks.signingMethod = jwt.SigningMethodHS256
+		ks.signingKey = ks.secret
+		return ks, nil
+	}
+
+	data, err := os.ReadFile(cfg.JwtSigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwt signing key: %w", err)
+	}
+	private, public, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwt signing key: %w", err)
+	}
+	vk, err := ks.addVerificationKey(public)
+	if err != nil {
+		return nil, err
+	}
+	ks.signingKID = vk.jwk.Kid
+	ks.signingMethod = vk.method
+	ks.signingKey = private
+
+	for _, file := range cfg.JwtVerificationKeyFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt verification key %s: %w", file, err)
+		}
+		public, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jwt verification key %s: %w", file, err)
+		}
+		if _, err := ks.addVerificationKey(public); err != nil {
+			return nil, err
+		}
+	}
+
+	return ks, nil
+}
+
+// JWKS returns the public verification keys, the signing key first
+func (ks *KeySet) JWKS() []JSONWebKey {
+	keys := make([]JSONWebKey, 0, len(ks.order))
+	for _, kid := range ks.order {
+		keys = append(keys, ks.verification[kid].jwk)
+	}
+	return keys
+}
+
+// MarshalJSON encodes the key set as a JWK set document
+func (ks *KeySet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string][]JSONWebKey{"keys": ks.JWKS()})
+}
+
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	if ks.signingKID != "" {
+		token.Header["kid"] = ks.signingKID
+	}
+	return token.SignedString(ks.signingKey)
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || ks.secret == nil {
+			return nil, ErrInvalidToken
+		}
+		return ks.secret, nil
+	}
+
+	vk, ok := ks.verification[kid]
+	if !ok || vk.method.Alg() != token.Method.Alg() {
+		return nil, ErrInvalidToken
+	}
+	return vk.key, nil
+}
+
+func (ks *KeySet) addVerificationKey(public crypto.PublicKey) (*verificationKey, error) {
+	vk := &verificationKey{key: public}
+
+	switch k := public.(type) {
+	case *rsa.PublicKey:
+		vk.method = jwt.SigningMethodRS256
+		vk.jwk = JSONWebKey{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		vk.method = jwt.SigningMethodEdDSA
+		vk.jwk = JSONWebKey{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt key type %T", public)
+	}
+	vk.jwk.Use = "sig"
+	vk.jwk.Alg = vk.method.Alg()
+	vk.jwk.Kid = thumbprint(vk.jwk)
+
+	if _, ok := ks.verification[vk.jwk.Kid]; !ok {
+		ks.verification[vk.jwk.Kid] = vk
+		ks.order = append(ks.order, vk.jwk.Kid)
+	}
+	return vk, nil
+}
+
+// thumbprint computes the RFC 7638 thumbprint of the key, used as its kid
+func thumbprint(jwk JSONWebKey) string {
+	var canonical string
+	switch jwk.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case "OKP":
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, jwk.Crv, jwk.X)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func parsePrivateKey(data []byte) (crypto.PrivateKey, crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return key, &key.PublicKey, nil
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		return key, key.(ed25519.PrivateKey).Public(), nil
+	}
+	return nil, nil, errors.New("key must be a PEM encoded RSA or Ed25519 private key")
+}
+
+// parsePublicKey accepts a public key or a private key of a retired signing key
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if _, public, err := parsePrivateKey(data); err == nil {
+		return public, nil
+	}
+	return nil, errors.New("key must be a PEM encoded RSA or Ed25519 key")
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRSAKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "rsa.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(file, data, 0600))
+	return file
+}
+
+func writeEd25519Key(t *testing.T) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "ed25519.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(file, data, 0600))
+	return file
+}
+
+var testTokenParams = &TokenParams{
+	UserID:   1,
+	Email:    "test@gmail.com",
+	UserType: "user",
+	Duration: time.Minute,
+}
+
+func TestKeySetHS256(t *testing.T) {
+	keys, err := NewKeySet(&config.Config{Authorization: "secret"})
+	require.NoError(t, err)
+
+	token, _, err := CreateToken(keys, testTokenParams)
+	require.NoError(t, err)
+
+	payload, err := VerifyToken(keys, token)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), payload.UserID)
+	require.Empty(t, keys.JWKS())
+}
+
+func TestKeySetRotation(t *testing.T) {
+	oldKey, newKey := writeRSAKey(t), writeEd25519Key(t)
+
+	oldKeys, err := NewKeySet(&config.Config{JwtSigningKeyFile: oldKey})
+	require.NoError(t, err)
+	oldToken, _, err := CreateToken(oldKeys, testTokenParams)
+	require.NoError(t, err)
+
+	keys, err := NewKeySet(&config.Config{
+		JwtSigningKeyFile:       newKey,
+		JwtVerificationKeyFiles: []string{oldKey},
+	})
+	require.NoError(t, err)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks, 2)
+	require.Equal(t, "EdDSA", jwks[0].Alg)
+	require.Equal(t, "RS256", jwks[1].Alg)
+	require.Equal(t, oldKeys.JWKS()[0].Kid, jwks[1].Kid)
+
+	newToken, _, err := CreateToken(keys, testTokenParams)
+	require.NoError(t, err)
+
+	_, err = VerifyToken(keys, newToken)
+	require.NoError(t, err)
+	_, err = VerifyToken(keys, oldToken)
+	require.NoError(t, err)
+
+	_, err = VerifyToken(oldKeys, newToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	hsKeys, err := NewKeySet(&config.Config{Authorization: "secret"})
+	require.NoError(t, err)
+	hsToken, _, err := CreateToken(hsKeys, testTokenParams)
+	require.NoError(t, err)
+	_, err = VerifyToken(keys, hsToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	data, err := json.Marshal(keys)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"keys":[`)
+}
+
+func TestKeySetRejectsSecretWithSigningKey(t *testing.T) {
+	for _, file := range []string{writeRSAKey(t), writeEd25519Key(t)} {
+		keys, err := NewKeySet(&config.Config{
+			Authorization:     "secret",
+			JwtSigningKeyFile: file,
+		})
+		require.NoError(t, err)
+
+		hsKeys, err := NewKeySet(&config.Config{Authorization: "secret"})
+		require.NoError(t, err)
+		hsToken, _, err := CreateToken(hsKeys, testTokenParams)
+		require.NoError(t, err)
+
+		_, err = VerifyToken(keys, hsToken)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	}
+}
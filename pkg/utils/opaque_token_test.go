@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpaqueToken(t *testing.T) {
+	token, err := GenerateOpaqueToken(32)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	other, err := GenerateOpaqueToken(32)
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+
+	require.Equal(t, HashOpaqueToken(token), HashOpaqueToken(token))
+	require.NotEqual(t, HashOpaqueToken(token), HashOpaqueToken(other))
+}
@@ -14,6 +14,7 @@ var (
 
 type Payload struct {
 	Id        uuid.UUID `json:"id"`
+	SessionID string    `json:"session_id,omitempty"`
 	UserID    int64     `json:"user_id"`
 	Email     string    `json:"email"`
 	UserType  string    `json:"user_type"`
@@ -29,6 +30,7 @@ func NewPayload(tokenParams *TokenParams) (*Payload, error) {
 
 	payload := &Payload{
 		Id:        tokenId,
+		SessionID: tokenParams.SessionID,
 		UserID:    tokenParams.UserID,
 		Email:     tokenParams.Email,
 		UserType:  tokenParams.UserType,
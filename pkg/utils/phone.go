@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
+// NormalizePhoneNumber converts the phone number to E.164 (+998901234567).
+// Numbers without an international prefix get the default country code,
+// after dropping the national trunk prefix 0.
+func NormalizePhoneNumber(phone, defaultCountryCode string) (string, error) {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		if defaultCountryCode == "" {
+			return "", ErrInvalidPhoneNumber
+		}
+		number = strings.TrimPrefix(defaultCountryCode, "+") + strings.TrimPrefix(number, "0")
+	}
+
+	// E.164 numbers have at most 15 digits and never start with 0
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	return "+" + number, nil
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	valid := map[string]string{
+		"+998 90 123-45-67":  "+998901234567",
+		"00998901234567":     "+998901234567",
+		"90 123 45 67":       "+998901234567",
+		"(090) 123 45 67":    "+998901234567",
+		"+1 (415) 555-0132":  "+14155550132",
+		" +44 20 7946 0958 ": "+442079460958",
+	}
+	for phone, want := range valid {
+		got, err := NormalizePhoneNumber(phone, "998")
+		require.NoError(t, err, phone)
+		require.Equal(t, want, got)
+	}
+
+	invalid := []string{"", "abc", "+0123456789", "12+345678", "+1234567890123456", "+12345"}
+	for _, phone := range invalid {
+		_, err := NormalizePhoneNumber(phone, "998")
+		require.ErrorIs(t, err, ErrInvalidPhoneNumber, phone)
+	}
+
+	_, err := NormalizePhoneNumber("90 123 45 67", "")
+	require.ErrorIs(t, err, ErrInvalidPhoneNumber)
+}
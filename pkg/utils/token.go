@@ -4,38 +4,29 @@ import (
 	"errors"
 	"time"
 
-	"github.com/SaidovZohid/medium_user_service/config"
 	"github.com/golang-jwt/jwt"
 )
 
 type TokenParams struct {
-	UserID   int64
-	Email    string
-	UserType string
-	Duration time.Duration
+	UserID    int64
+	SessionID string
+	Email     string
+	UserType  string
+	Duration  time.Duration
 }
 
-func CreateToken(cfg *config.Config, tokenParams *TokenParams) (string, *Payload, error) {
+func CreateToken(keys *KeySet, tokenParams *TokenParams) (string, *Payload, error) {
 	payload, err := NewPayload(tokenParams)
 	if err != nil {
 		return "", nil, err
 	}
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-	token, err := jwtToken.SignedString([]byte(cfg.Authorization))
+	token, err := keys.sign(payload)
 	return token, payload, err
 }
 
-func VerifyToken(cfg *config.Config, token string) (*Payload, error) {
-	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(cfg.Authorization), nil
-	}
-
-	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+func VerifyToken(keys *KeySet, token string) (*Payload, error) {
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keys.keyFunc)
 
 	if err != nil {
 		verr, ok := err.(*jwt.ValidationError)
@@ -51,4 +42,4 @@ func VerifyToken(cfg *config.Config, token string) (*Payload, error) {
 	}
 
 	return payload, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 test vectors for SHA1, truncated to six digits
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range cases {
+		code, err := TOTPCode(secret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, want, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	require.True(t, ValidateTOTP(secret, code, now))
+	require.True(t, ValidateTOTP(secret, code, now.Add(30*time.Second)))
+	require.False(t, ValidateTOTP(secret, code, now.Add(5*time.Minute)))
+	require.False(t, ValidateTOTP(secret, "abc", now))
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// CreateUnsubscribeToken returns a token that unsubscribes the user from a
+// category of notifications. It is signed instead of stored, so the links of
+// old emails keep working.
+func CreateUnsubscribeToken(secret string, userID int64, category string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(userID, 10) + ":" + category))
+	return payload + "." + signUnsubscribePayload(secret, payload)
+}
+
+// ParseUnsubscribeToken returns the user and the category of a token made by
+// CreateUnsubscribeToken. Every token is invalid with an empty secret.
+func ParseUnsubscribeToken(secret, token string) (int64, string, error) {
+	if secret == "" {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signUnsubscribePayload(secret, payload))) {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	id, category, ok := strings.Cut(string(data), ":")
+	if !ok {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	return userID, category, nil
+}
+
+func signUnsubscribePayload(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	token := CreateUnsubscribeToken("secret", 42, "social")
+
+	userID, category, err := ParseUnsubscribeToken("secret", token)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), userID)
+	require.Equal(t, "social", category)
+
+	_, _, err = ParseUnsubscribeToken("other secret", token)
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+
+	forged := CreateUnsubscribeToken("secret", 43, "social")
+	_, _, err = ParseUnsubscribeToken("secret", token[:len(token)/2]+forged[len(forged)/2:])
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+
+	_, _, err = ParseUnsubscribeToken("secret", "not-a-token")
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+
+	unsigned := CreateUnsubscribeToken("", 42, "social")
+	_, _, err = ParseUnsubscribeToken("", unsigned)
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreAccount logs in a deleted user with the password and undoes the
+// deletion while the restore window is open.
+func (s *AuthService) RestoreAccount(ctx context.Context, req *pb.RestoreAccountRequest) (*pb.AuthResponse, error) {
+	req.Email = utils.NormalizeEmail(req.Email)
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+
+	user, err := s.deletedUserByLogin(req.Email, req.PhoneNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := s.unknownLogin(req.Email, req.PhoneNumber, ipAddress); status.Code(err) != codes.NotFound {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.NotFound, "deleted account not found")
+		}
+		s.logger.WithError(err).Error("failed to get deleted user in RestoreAccount func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	// Keyed on the user id like Login, so both share the lockout of the account
+	key := strconv.FormatInt(user.ID, 10)
+	if err := s.checkAttempts(LoginAttempt, key, ipAddress); err != nil {
+		return nil, err
+	}
+	err = utils.CheckPassword(req.Password, user.Password)
+	if err != nil {
+		s.recordFailedAttempt(LoginAttempt, key, ipAddress)
+		return nil, status.Errorf(codes.Internal, "incorrect_password")
+	}
+	s.resetAttempts(LoginAttempt, key)
+
+	// The account stays deleted until the second factor is passed, VerifyMFA
+	// restores it then
+	challenge, err := s.mfaChallenge(user)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create mfa challenge in RestoreAccount func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if challenge != nil {
+		err = s.inMemory.Set(MFARestoreKey+utils.HashOpaqueToken(challenge.MfaToken), "1", mfaChallengeDuration)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to save mfa restore in RestoreAccount func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		return challenge, nil
+	}
+
+	if err := s.restoreAccount(user.ID, "RestoreAccount"); err != nil {
+		return nil, err
+	}
+	user.DeletedAt = nil
+
+	res, err := s.newAuthResponse(ctx, user, "")
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create tokens in RestoreAccount func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
+
+// restoreAccount undoes the deletion of the user if the restore window is
+// still open
+func (s *AuthService) restoreAccount(userID int64, funcName string) error {
+	err := s.storage.User().Restore(userID, time.Now().Add(-s.cfg.AccountRestoreWindow))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.FailedPrecondition, "the account can not be restored anymore")
+		}
+		s.logger.WithError(err).Errorf("failed to restore user in %s func", funcName)
+		return status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	return nil
+}
+
+// deletedUserByLogin works like userByLogin for deleted users
+func (s *AuthService) deletedUserByLogin(email, phoneNumber string) (*repo.User, error) {
+	if email != "" || phoneNumber == "" {
+		return s.storage.User().GetDeleted(email, "")
+	}
+
+	phoneNumber, err := utils.NormalizePhoneNumber(phoneNumber, s.cfg.DefaultPhoneCountryCode)
+	if err != nil {
+		return nil, sql.ErrNoRows
+	}
+	user, err := s.storage.User().GetDeleted("", phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if user.PhoneVerifiedAt == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return user, nil
+}
+
+// unknownLogin counts a login with an email or phone number without an
+// account against the normalized identifier and the ip, so the ip lockout
+// covers guessing accounts as well. It returns codes.NotFound unless the
+// attempts are exhausted.
+func (s *AuthService) unknownLogin(email, phoneNumber, ipAddress string) error {
+	login := email
+	if login == "" {
+		login = strings.TrimSpace(phoneNumber)
+		if normalized, err := utils.NormalizePhoneNumber(phoneNumber, s.cfg.DefaultPhoneCountryCode); err == nil {
+			login = normalized
+		}
+	}
+
+	if err := s.checkAttempts(LoginAttempt, login, ipAddress); err != nil {
+		return err
+	}
+	s.recordFailedAttempt(LoginAttempt, login, ipAddress)
+	return status.Errorf(codes.NotFound, "user not found")
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	AttemptsEmailKey = "attempts_email_"
+	AttemptsIPKey    = "attempts_ip_"
+	BackoffKey       = "attempts_backoff_"
+	LockoutKey       = "attempts_lockout_"
+	FailuresKey      = "attempts_failures_"
+	CodeAttemptsKey  = "code_attempts_"
+)
+
+const (
+	LoginAttempt          = "login"
+	VerifyAttempt         = "verify"
+	ForgotPasswordAttempt = "forgot_password"
+)
+
+const (
+	// attemptsWindow is the sliding window failed attempts are counted in.
+	attemptsWindow = 15 * time.Minute
+	// maxEmailAttempts attempts for one email within the window lock the account.
+	maxEmailAttempts = 10
+	// maxIPAttempts failed attempts from one ip within the window block the ip.
+	maxIPAttempts = 50
+	// lockoutDuration is how long a locked account stays locked.
+	lockoutDuration = 30 * time.Minute
+	// baseBackoff is doubled with every failed attempt until maxBackoff.
+	baseBackoff = time.Second
+	maxBackoff  = 5 * time.Minute
+	// maxCodeAttempts wrong guesses invalidate a verification code.
+	maxCodeAttempts = 5
+	// codeAttemptsTTL outlives every verification code.
+	codeAttemptsTTL = time.Hour
+)
+
+// attemptLimiter slows down guessing of passwords and codes, the services
+// embed it
+type attemptLimiter struct {
+	inMemory storage.InMemoryStorageI
+	logger   *logrus.Logger
+}
+
+// checkAttempts rejects the request with codes.ResourceExhausted when the
+// email is locked out, still in its backoff period or the ip made too many
+// failed attempts recently. The attempt is counted before the caller checks
+// the secret, so parallel guesses can not all pass the check at once.
+func (l *attemptLimiter) checkAttempts(scope, email, ipAddress string) error {
+	keys := []string{LockoutKey + scope + "_" + email, BackoffKey + scope + "_" + email}
+	if ipAddress != "" {
+		keys = append(keys, LockoutKey+scope+"_ip_"+ipAddress)
+	}
+	for _, key := range keys {
+		ttl, err := l.inMemory.TTL(key)
+		if err == nil {
+			return tooManyAttempts(ttl)
+		}
+		if !errors.Is(err, storage.ErrKeyNotFound) {
+			l.logger.WithError(err).Error("failed to check attempts")
+			return status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+	}
+
+	attempts, err := l.inMemory.AddToWindow(AttemptsEmailKey+scope+"_"+email, attemptsWindow)
+	if err != nil {
+		l.logger.WithError(err).Error("failed to record attempt")
+		return status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if attempts > maxEmailAttempts {
+		l.logger.WithField("email", email).Warn("too many failed attempts, locking account")
+		if err := l.inMemory.Set(LockoutKey+scope+"_"+email, "1", lockoutDuration); err != nil {
+			l.logger.WithError(err).Error("failed to lock account")
+		}
+		return tooManyAttempts(lockoutDuration)
+	}
+
+	return nil
+}
+
+// recordFailedAttempt sets an exponential backoff for the email and counts
+// the failed attempt for the ip, blocking the ip after too many.
+func (l *attemptLimiter) recordFailedAttempt(scope, email, ipAddress string) {
+	failures, err := l.inMemory.Incr(FailuresKey+scope+"_"+email, attemptsWindow)
+	if err != nil {
+		l.logger.WithError(err).Error("failed to record failed attempt")
+	} else if err := l.inMemory.Set(BackoffKey+scope+"_"+email, "1", backoff(failures)); err != nil {
+		l.logger.WithError(err).Error("failed to record failed attempt")
+	}
+
+	if ipAddress == "" {
+		return
+	}
+	failures, err = l.inMemory.AddToWindow(AttemptsIPKey+scope+"_"+ipAddress, attemptsWindow)
+	if err != nil {
+		l.logger.WithError(err).Error("failed to record failed attempt")
+		return
+	}
+	if failures >= maxIPAttempts {
+		l.logger.WithField("ip_address", ipAddress).Warn("too many failed attempts, blocking ip")
+		if err := l.inMemory.Set(LockoutKey+scope+"_ip_"+ipAddress, "1", lockoutDuration); err != nil {
+			l.logger.WithError(err).Error("failed to record failed attempt")
+		}
+	}
+}
+
+// resetAttempts forgets the failed attempts of the email after a success.
+func (l *attemptLimiter) resetAttempts(scope, email string) {
+	err := l.inMemory.Delete(
+		AttemptsEmailKey+scope+"_"+email,
+		FailuresKey+scope+"_"+email,
+		BackoffKey+scope+"_"+email,
+	)
+	if err != nil {
+		l.logger.WithError(err).Error("failed to reset attempts")
+	}
+}
+
+var (
+	errCodeExpired   = errors.New("code expired")
+	errIncorrectCode = errors.New("incorrect code")
+)
+
+// checkCode compares the code with the one stored at codeKey. The guess is
+// counted before comparing and after maxCodeAttempts guesses the code is
+// deleted, so parallel guesses can not exceed the limit.
+func (l *attemptLimiter) checkCode(codeKey, code string) error {
+	attempts, err := l.inMemory.Incr(CodeAttemptsKey+codeKey, codeAttemptsTTL)
+	if err != nil {
+		return err
+	}
+	if attempts > maxCodeAttempts {
+		if err := l.inMemory.Delete(codeKey); err != nil {
+			l.logger.WithError(err).Error("failed to invalidate code")
+		}
+		return errIncorrectCode
+	}
+
+	expected, err := l.inMemory.Get(codeKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return errCodeExpired
+		}
+		return err
+	}
+	if expected != code {
+		return errIncorrectCode
+	}
+
+	if err := l.inMemory.Delete(codeKey, CodeAttemptsKey+codeKey); err != nil {
+		l.logger.WithError(err).Error("failed to delete used code")
+	}
+	return nil
+}
+
+func backoff(failures int64) time.Duration {
+	d := baseBackoff
+	for i := int64(1); i < failures && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+func tooManyAttempts(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "too many attempts, try again later")
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
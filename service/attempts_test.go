@@ -0,0 +1,47 @@
+package service
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Second, backoff(1))
+	require.Equal(t, 2*time.Second, backoff(2))
+	require.Equal(t, 8*time.Second, backoff(4))
+	require.Equal(t, maxBackoff, backoff(100))
+}
+
+func TestTooManyAttempts(t *testing.T) {
+	st := status.Convert(tooManyAttempts(30 * time.Second))
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Equal(t, 30*time.Second, info.RetryDelay.AsDuration())
+}
+
+func TestCheckCodeRejectsAfterMaxAttempts(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	inMemory := newFakeInMemory()
+	limiter := &attemptLimiter{inMemory: inMemory, logger: log}
+
+	require.NoError(t, inMemory.Set("code_key", "123456", time.Minute))
+	for i := 0; i < maxCodeAttempts; i++ {
+		require.ErrorIs(t, limiter.checkCode("code_key", "000000"), errIncorrectCode)
+	}
+
+	// the right code is not even compared anymore
+	require.ErrorIs(t, limiter.checkCode("code_key", "123456"), errIncorrectCode)
+	_, err := inMemory.Get("code_key")
+	require.Error(t, err)
+}
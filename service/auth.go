@@ -6,15 +6,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/SaidovZohid/medium_user_service/config"
-	"github.com/SaidovZohid/medium_user_service/genproto/notification_service"
 	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
-	grpcPkg "github.com/SaidovZohid/medium_user_service/pkg/grpc_client"
+	"github.com/SaidovZohid/medium_user_service/pkg/oauth"
+	"github.com/SaidovZohid/medium_user_service/pkg/policy"
 	"github.com/SaidovZohid/medium_user_service/pkg/utils"
 	"github.com/SaidovZohid/medium_user_service/storage"
 	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"google.golang.org/grpc/codes"
@@ -24,34 +26,51 @@ import (
 
 type AuthService struct {
 	pb.UnimplementedAuthServiceServer
-	storage    storage.StorageI
-	inMemory   storage.InMemoryStorageI
-	grpcClient grpcPkg.GrpcClientI
-	cfg        *config.Config
-	logger     *logrus.Logger
+	attemptLimiter
+	storage  storage.StorageI
+	inMemory storage.InMemoryStorageI
+	notifier *Notifier
+	cfg      *config.Config
+	keys     *utils.KeySet
+	logger   *logrus.Logger
+
+	oauthProviders map[string]oauth.Provider
 }
 
-func NewAuthService(strg storage.StorageI, inMemory storage.InMemoryStorageI, grpc grpcPkg.GrpcClientI, cfg *config.Config, log *logrus.Logger) *AuthService {
+func NewAuthService(strg storage.StorageI, inMemory storage.InMemoryStorageI, notifier *Notifier, cfg *config.Config, keys *utils.KeySet, log *logrus.Logger) *AuthService {
 	return &AuthService{
-		storage:    strg,
-		inMemory:   inMemory,
-		grpcClient: grpc,
-		cfg:        cfg,
-		logger:     log,
+		attemptLimiter: attemptLimiter{
+			inMemory: inMemory,
+			logger:   log,
+		},
+		storage:  strg,
+		inMemory: inMemory,
+		notifier: notifier,
+		cfg:      cfg,
+		keys:     keys,
+		logger:   log,
+
+		oauthProviders: oauth.NewProviders(cfg),
 	}
 }
 
 const (
-	RegisterCodeKey   = "register_code_"
-	ForgotPasswordKey = "forgot_password_code_"
+	RegisterCodeKey       = "register_code_"
+	ForgotPasswordKey     = "forgot_password_code_"
+	RevokedTokenKey       = "revoked_token_"
+	TokensIssuedBeforeKey = "tokens_issued_before_"
 )
 
+const forgotPasswordTokenDuration = time.Minute * 30
+
 const (
 	VerificationEmail   = "verification_email"
 	ForgotPasswordEmail = "forgot_password_email"
 )
 
 func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*emptypb.Empty, error) {
+	req.Email = utils.NormalizeEmail(req.Email)
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to hash password")
@@ -100,9 +119,10 @@ func (s *AuthService) sendVereficationCode(key, email, email_type string) error
 		s.logger.WithError(err).Error("failed to send generated code in sendVerificationCode func")
 		return err
 	}
-	_, err = s.grpcClient.NotificationService().SendEmail(context.Background(), &notification_service.SendEmailRequest{
-		To:      email,
-		Subject: "Verification Email",
+	err = s.notifier.SendEmail(context.Background(), &Email{
+		Category: NotificationSecurity,
+		To:       email,
+		Subject:  "Verification Email",
 		Body: map[string]string{
 			"code": code,
 		},
@@ -116,6 +136,8 @@ func (s *AuthService) sendVereficationCode(key, email, email_type string) error
 }
 
 func (s *AuthService) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.AuthResponse, error) {
+	req.Email = utils.NormalizeEmail(req.Email)
+
 	userData, err := s.inMemory.Get("user_" + req.Email)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get user data from redis in verify func")
@@ -128,79 +150,219 @@ func (s *AuthService) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.Au
 		return nil, status.Errorf(codes.Internal, "internal server errror: %v", err)
 	}
 
-	code, err := s.inMemory.Get(RegisterCodeKey + user.Email)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "code_expired")
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	if err := s.checkAttempts(VerifyAttempt, user.Email, ipAddress); err != nil {
+		return nil, err
 	}
-	if code != req.Code {
-		return nil, status.Errorf(codes.Unknown, "incorrect_code", err)
+
+	err = s.checkCode(RegisterCodeKey+user.Email, req.Code)
+	if err != nil {
+		if errors.Is(err, errCodeExpired) {
+			return nil, status.Errorf(codes.NotFound, "code_expired")
+		}
+		if errors.Is(err, errIncorrectCode) {
+			s.recordFailedAttempt(VerifyAttempt, user.Email, ipAddress)
+			return nil, status.Errorf(codes.Unknown, "incorrect_code")
+		}
+		s.logger.WithError(err).Error("failed to check code in verify func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
+	s.resetAttempts(VerifyAttempt, user.Email)
 
 	result, err := s.storage.User().Create(&user)
 	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "email is already taken")
+		}
 		s.logger.WithError(err).Error("failed to create user in verify func")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
-	token, _, err := utils.CreateToken(s.cfg, &utils.TokenParams{
-		UserID:   user.ID,
-		Email:    user.Email,
-		UserType: user.Type,
-		Duration: time.Hour * 24 * 360,
-	})
+
+	res, err := s.newAuthResponse(ctx, result, "")
 	if err != nil {
-		s.logger.WithError(err).Error("failed to create token in verify func")
+		s.logger.WithError(err).Error("failed to create tokens in verify func")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	return &pb.AuthResponse{
-		Id:          result.ID,
-		FirstName:   result.FirstName,
-		LastName:    result.LastName,
-		Email:       result.Email,
-		Type:        result.Type,
-		CreatedAt:   result.CreatedAt.Format(time.RFC3339),
-		AccessToken: token,
-	}, nil
+	return res, nil
 }
 
 func (s *AuthService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
-	user, err := s.storage.User().GetByEmail(req.Email)
+	req.Email = utils.NormalizeEmail(req.Email)
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+
+	user, err := s.userByLogin(req.Email, req.PhoneNumber)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get user by email in login func")
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+			return nil, s.unknownLogin(req.Email, req.PhoneNumber, ipAddress)
 		}
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
+	key := strconv.FormatInt(user.ID, 10)
+	if err := s.checkAttempts(LoginAttempt, key, ipAddress); err != nil {
+		return nil, err
+	}
 	err = utils.CheckPassword(req.Password, user.Password)
 	if err != nil {
+		s.recordFailedAttempt(LoginAttempt, key, ipAddress)
 		return nil, status.Errorf(codes.Internal, "incorrect_password")
 	}
+	s.resetAttempts(LoginAttempt, key)
 
-	token, _, err := utils.CreateToken(s.cfg, &utils.TokenParams{
-		UserID:   user.ID,
-		Email:    user.Email,
-		UserType: user.Type,
-		Duration: time.Hour * 24 * 360,
-	})
+	challenge, err := s.mfaChallenge(user)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to create token")
+		s.logger.WithError(err).Error("failed to create mfa challenge in login func")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
+	if challenge != nil {
+		return challenge, nil
+	}
+
+	res, err := s.newAuthResponse(ctx, user, "")
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create tokens in login func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
+
+// newAuthResponse issues a short-lived access token together with a refresh
+// token. An empty sessionID starts a new session for the caller's device,
+// otherwise the tokens are issued for the existing session, whose id is also
+// the refresh token family id.
+func (s *AuthService) newAuthResponse(ctx context.Context, user *repo.User, sessionID string) (*pb.AuthResponse, error) {
+	newSession := sessionID == ""
+	if newSession {
+		sessionID = uuid.NewString()
+	}
+
+	accessToken, payload, err := utils.CreateToken(s.keys, &utils.TokenParams{
+		UserID:    user.ID,
+		SessionID: sessionID,
+		Email:     user.Email,
+		UserType:  user.Type,
+		Duration:  s.cfg.AccessTokenDuration,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if newSession {
+		userAgent, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+		_, err = s.storage.Session().Create(&repo.Session{
+			ID:        sessionID,
+			UserID:    user.ID,
+			TokenID:   payload.Id.String(),
+			UserAgent: userAgent,
+			IpAddress: ipAddress,
+		})
+	} else {
+		err = s.storage.Session().UpdateToken(sessionID, payload.Id.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.storage.RefreshToken().Create(&repo.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  sessionID,
+		TokenHash: utils.HashOpaqueToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.cfg.RefreshTokenDuration),
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &pb.AuthResponse{
-		Id:          user.ID,
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		Email:       user.Email,
-		Type:        user.Type,
-		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
-		AccessToken: token,
+		Id:           user.ID,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Email:        user.Email,
+		Type:         user.Type,
+		CreatedAt:    user.CreatedAt.Format(time.RFC3339),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	}, nil
 }
 
+func (s *AuthService) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.AuthResponse, error) {
+	token, err := s.storage.RefreshToken().GetByHash(utils.HashOpaqueToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token")
+		}
+		s.logger.WithError(err).Error("failed to get refresh token in RefreshToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	if token.RevokedAt != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token has been revoked")
+	}
+
+	if token.RotatedAt != nil {
+		return nil, s.revokeReusedFamily(token)
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token is expired")
+	}
+
+	err = s.storage.RefreshToken().Rotate(token.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.revokeReusedFamily(token)
+		}
+		s.logger.WithError(err).Error("failed to rotate refresh token in RefreshToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	user, err := s.storage.User().Get(token.UserID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user in RefreshToken func")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Unauthenticated, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	res, err := s.newAuthResponse(ctx, user, token.FamilyID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create tokens in RefreshToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
+
+// revokeReusedFamily is called when an already rotated refresh token is
+// presented again. That means the token has leaked, so every token of the
+// family is revoked and the user has to log in again.
+func (s *AuthService) revokeReusedFamily(token *repo.RefreshToken) error {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":   token.UserID,
+		"family_id": token.FamilyID,
+	}).Warn("refresh token reuse detected, revoking token family")
+
+	err := s.storage.RefreshToken().RevokeFamily(token.FamilyID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to revoke refresh token family")
+		return status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return status.Errorf(codes.Unauthenticated, "refresh token has already been used")
+}
+
 func (s *AuthService) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordRequest) (*emptypb.Empty, error) {
+	req.Email = utils.NormalizeEmail(req.Email)
+
 	_, err := s.storage.User().GetByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -220,16 +382,23 @@ func (s *AuthService) ForgotPassword(ctx context.Context, req *pb.ForgotPassword
 }
 
 func (s *AuthService) VerifyForgotPassword(ctx context.Context, req *pb.VerifyRequest) (*pb.AuthResponse, error) {
-	code, err := s.inMemory.Get(ForgotPasswordKey + req.Email)
+	req.Email = utils.NormalizeEmail(req.Email)
+
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	if err := s.checkAttempts(ForgotPasswordAttempt, req.Email, ipAddress); err != nil {
+		return nil, err
+	}
+
+	err := s.checkCode(ForgotPasswordKey+req.Email, req.Code)
 	if err != nil {
+		if errors.Is(err, errIncorrectCode) {
+			s.recordFailedAttempt(ForgotPasswordAttempt, req.Email, ipAddress)
+			return nil, status.Errorf(codes.Internal, "verification code is not true: %v", err)
+		}
 		s.logger.WithError(err).Error("failed to get code from redis in VerifyForgoPasword func")
 		return nil, status.Errorf(codes.Internal, "verification code has been expired: %v", err)
 	}
-
-	if req.Code != code {
-		return nil, status.Errorf(codes.Internal, "verification code is not true: %v", err)
-
-	}
+	s.resetAttempts(ForgotPasswordAttempt, req.Email)
 
 	result, err := s.storage.User().GetByEmail(req.Email)
 	if err != nil {
@@ -237,11 +406,22 @@ func (s *AuthService) VerifyForgotPassword(ctx context.Context, req *pb.VerifyRe
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	token, _, err := utils.CreateToken(s.cfg, &utils.TokenParams{
+	// The emailed code only replaces the password, a second factor is still
+	// needed before the user gets a token
+	challenge, err := s.mfaChallenge(result)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create mfa challenge in VerifyForgotPassword func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if challenge != nil {
+		return challenge, nil
+	}
+
+	token, _, err := utils.CreateToken(s.keys, &utils.TokenParams{
 		UserID:   result.ID,
 		Email:    result.Email,
 		UserType: result.Type,
-		Duration: time.Minute * 30,
+		Duration: forgotPasswordTokenDuration,
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to create token in VerifyForgotPassword func")
@@ -279,31 +459,179 @@ func (s *AuthService) UpdatePassword(ctx context.Context, req *pb.UpdatePassword
 }
 
 func (s *AuthService) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.AuthPayload, error) {
-	accessToken := req.AccessToken
+	payload, scopes, err := s.authenticate(req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
 
-	payload, err := utils.VerifyToken(s.cfg, accessToken)
+	rules, err := s.rules(payload.UserType, scopes)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to verify token in VerifyToken func")
 		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 	}
 
-	hasPermission, err := s.storage.Permission().CheckPermission(&repo.Permission{
-		UserType: payload.UserType,
-		Resource: req.Resource,
-		Action:   req.Action,
+	payload.HasPermission = policy.Evaluate(rules, policy.Request{
+		SubjectID: payload.UserId,
+		Resource:  req.Resource,
+		Action:    req.Action,
+		OwnerID:   resourceOwnerID(req.ResourceOwnerId, req.Resource, req.ResourceId),
 	})
+
+	return payload, nil
+}
+
+// verifyAccessToken checks the signature and expiry of the token and makes
+// sure it has not been revoked by Logout or LogoutAll.
+func (s *AuthService) verifyAccessToken(accessToken string) (*utils.Payload, error) {
+	payload, err := utils.VerifyToken(s.keys, accessToken)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to verify token in VerifyToken func")
+		s.logger.WithError(err).Error("failed to verify token")
 		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 	}
 
-	return &pb.AuthPayload{
-		Id:            payload.Id.String(),
-		UserId:        payload.UserID,
-		Email:         payload.Email,
-		UserType:      payload.UserType,
-		IssuedAt:      payload.IssuedAt.Format(time.RFC3339),
-		ExpiredAt:     payload.ExpiredAt.Format(time.RFC3339),
-		HasPermission: hasPermission,
-	}, nil
-}
\ No newline at end of file
+	_, err = s.inMemory.Get(RevokedTokenKey + payload.Id.String())
+	if err == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "token has been revoked")
+	}
+	if !errors.Is(err, storage.ErrKeyNotFound) {
+		s.logger.WithError(err).Error("failed to check revoked token")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	issuedBefore, err := s.inMemory.Get(TokensIssuedBeforeKey + strconv.FormatInt(payload.UserID, 10))
+	if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		s.logger.WithError(err).Error("failed to get tokens issued before watermark")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if err == nil {
+		watermark, err := strconv.ParseInt(issuedBefore, 10, 64)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to parse tokens issued before watermark")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		if payload.IssuedAt.UnixNano() < watermark {
+			return nil, status.Errorf(codes.Unauthenticated, "token has been revoked")
+		}
+	}
+
+	return payload, nil
+}
+
+func (s *AuthService) Logout(ctx context.Context, req *pb.LogoutRequest) (*emptypb.Empty, error) {
+	payload, err := s.verifyAccessToken(req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.revokeAccessToken(payload)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to revoke access token in Logout func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	if payload.SessionID != "" {
+		err = s.revokeSession(payload.SessionID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			s.logger.WithError(err).Error("failed to revoke session in Logout func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+	}
+
+	if req.RefreshToken != "" {
+		token, err := s.storage.RefreshToken().GetByHash(utils.HashOpaqueToken(req.RefreshToken))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &emptypb.Empty{}, nil
+			}
+			s.logger.WithError(err).Error("failed to get refresh token in Logout func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		if token.UserID != payload.UserID {
+			return nil, status.Errorf(codes.PermissionDenied, "refresh token belongs to another user")
+		}
+
+		err = s.storage.RefreshToken().RevokeFamily(token.FamilyID)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to revoke refresh token in Logout func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*emptypb.Empty, error) {
+	payload, err := s.verifyAccessToken(req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = revokeAllTokens(s.storage, s.inMemory, s.cfg, payload.UserID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to revoke tokens in LogoutAll func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// revokeAccessToken remembers the token id until the token expires on its own.
+func (s *AuthService) revokeAccessToken(payload *utils.Payload) error {
+	ttl := time.Until(payload.ExpiredAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.inMemory.Set(RevokedTokenKey+payload.Id.String(), "1", ttl)
+}
+
+// revokeAllTokens revokes every refresh token of the user and moves the
+// "tokens issued before" watermark, so that all access tokens issued until
+// now are rejected by VerifyToken.
+func revokeAllTokens(strg storage.StorageI, inMemory storage.InMemoryStorageI, cfg *config.Config, userID int64) error {
+	err := strg.RefreshToken().RevokeAllByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	err = strg.Session().RevokeAllByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	return expireAccessTokens(inMemory, cfg, userID)
+}
+
+// expireAccessTokens makes VerifyToken reject every access token issued to
+// the user so far, refresh tokens keep working.
+func expireAccessTokens(inMemory storage.InMemoryStorageI, cfg *config.Config, userID int64) error {
+	// No access token lives longer than this, so the watermark may expire afterwards.
+	ttl := cfg.AccessTokenDuration
+	if ttl < forgotPasswordTokenDuration {
+		ttl = forgotPasswordTokenDuration
+	}
+
+	return inMemory.Set(
+		TokensIssuedBeforeKey+strconv.FormatInt(userID, 10),
+		strconv.FormatInt(time.Now().UnixNano(), 10),
+		ttl,
+	)
+}
+
+func (s *AuthService) GetJWKS(ctx context.Context, req *emptypb.Empty) (*pb.JWKSResponse, error) {
+	var res pb.JWKSResponse
+	for _, key := range s.keys.JWKS() {
+		res.Keys = append(res.Keys, &pb.JSONWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+
+	return &res, nil
+}
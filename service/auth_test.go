@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/oauth"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyForgotPasswordRequiresMFA(t *testing.T) {
+	strg := newFakeStorage()
+	inMemory := newFakeInMemory()
+	s := newTestAuthService(strg, inMemory)
+
+	enabledAt := time.Now()
+	user := testUser()
+	strg.users.users[user.ID] = user
+	strg.mfa.mfa[user.ID] = &repo.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", EnabledAt: &enabledAt}
+	inMemory.values[ForgotPasswordKey+user.Email] = "123456"
+
+	res, err := s.VerifyForgotPassword(context.Background(), &pb.VerifyRequest{
+		Email: user.Email,
+		Code:  "123456",
+	})
+	require.NoError(t, err)
+	require.True(t, res.MfaRequired)
+	require.NotEmpty(t, res.MfaToken)
+	require.Empty(t, res.AccessToken)
+}
+
+func TestRestoreAccountRequiresMFA(t *testing.T) {
+	strg := newFakeStorage()
+	inMemory := newFakeInMemory()
+	s := newTestAuthService(strg, inMemory)
+
+	password, err := utils.HashPassword("secret123")
+	require.NoError(t, err)
+	deletedAt := time.Now()
+	enabledAt := time.Now()
+	user := testUser()
+	user.Password = password
+	user.DeletedAt = &deletedAt
+	strg.users.users[user.ID] = user
+	strg.mfa.mfa[user.ID] = &repo.MFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", EnabledAt: &enabledAt}
+
+	res, err := s.RestoreAccount(context.Background(), &pb.RestoreAccountRequest{
+		Email:    user.Email,
+		Password: "secret123",
+	})
+	require.NoError(t, err)
+	require.True(t, res.MfaRequired)
+	require.Empty(t, res.AccessToken)
+	require.NotNil(t, user.DeletedAt)
+}
+
+func TestLoginBackoffIgnoresEmailCase(t *testing.T) {
+	strg := newFakeStorage()
+	inMemory := newFakeInMemory()
+	s := newTestAuthService(strg, inMemory)
+
+	password, err := utils.HashPassword("secret123")
+	require.NoError(t, err)
+	user := testUser()
+	user.Password = password
+	strg.users.users[user.ID] = user
+
+	_, err = s.Login(context.Background(), &pb.LoginRequest{
+		Email:    strings.ToUpper(user.Email),
+		Password: "wrong",
+	})
+	require.Error(t, err)
+
+	_, err = s.Login(context.Background(), &pb.LoginRequest{
+		Email:    user.Email,
+		Password: "wrong",
+	})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestVerifyPersonalAccessToken(t *testing.T) {
+	strg := newFakeStorage()
+	s := newTestAuthService(strg, newFakeInMemory())
+
+	user := testUser()
+	strg.users.users[user.ID] = user
+	lastUsedAt := time.Now()
+	strg.tokens.tokens = []*repo.PersonalAccessToken{{
+		ID:         1,
+		UserID:     user.ID,
+		TokenHash:  utils.HashOpaqueToken("mpat_token"),
+		Prefix:     "mpat_tok",
+		LastUsedAt: &lastUsedAt,
+	}}
+
+	payload, _, err := s.verifyPersonalAccessToken("mpat_token")
+	require.NoError(t, err)
+	require.Equal(t, user.ID, payload.UserId)
+	require.Zero(t, strg.tokens.lastUsedSet)
+
+	deletedAt := time.Now()
+	user.DeletedAt = &deletedAt
+	_, _, err = s.verifyPersonalAccessToken("mpat_token")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestOAuthLoginWithDeletedEmail(t *testing.T) {
+	strg := newFakeStorage()
+	s := newTestAuthService(strg, newFakeInMemory())
+
+	deletedAt := time.Now()
+	user := testUser()
+	user.DeletedAt = &deletedAt
+	strg.users.users[user.ID] = user
+
+	_, err := s.userByIdentity(&oauth.Identity{
+		Provider:      "google",
+		Subject:       "1",
+		Email:         user.Email,
+		EmailVerified: true,
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestOAuthLoginWithDeletedLinkedUser(t *testing.T) {
+	strg := newFakeStorage()
+	s := newTestAuthService(strg, newFakeInMemory())
+
+	deletedAt := time.Now()
+	user := testUser()
+	user.DeletedAt = &deletedAt
+	strg.users.users[user.ID] = user
+	strg.identities.identities["google_1"] = &repo.Identity{UserID: user.ID, Provider: "google", Subject: "1"}
+
+	_, err := s.userByIdentity(&oauth.Identity{Provider: "google", Subject: "1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCheckMFACodeRejectsReplay(t *testing.T) {
+	s := newTestAuthService(newFakeStorage(), newFakeInMemory())
+
+	mfa := &repo.MFA{UserID: 1, Secret: "JBSWY3DPEHPK3PXP"}
+	code, err := utils.TOTPCode(mfa.Secret, time.Now())
+	require.NoError(t, err)
+
+	ok, err := s.checkMFACode(mfa, code)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = s.checkMFACode(mfa, code)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/google/uuid"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// avatarSizes are the variants made of every avatar, the profile image
+// is the one of profileImageSize
+var avatarSizes = []int{64, 256, 512}
+
+const profileImageSize = 256
+
+// maxAvatarPixels limits the decoded image, about 160MB of memory
+const maxAvatarPixels = 40_000_000
+
+func (s *UserService) UploadAvatar(stream pb.UserService_UploadAvatarServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "user_id must be sent first")
+	}
+	userID := req.GetUserId()
+	if userID == 0 {
+		return status.Errorf(codes.InvalidArgument, "user_id must be sent first")
+	}
+
+	var data bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if int64(data.Len()+len(req.GetChunk())) > s.cfg.AvatarMaxSize {
+			return status.Errorf(codes.InvalidArgument, "image must be at most %d bytes", s.cfg.AvatarMaxSize)
+		}
+		data.Write(req.GetChunk())
+	}
+
+	img, err := utils.DecodeImage(data.Bytes(), maxAvatarPixels)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid image: %v", err)
+	}
+
+	// Every upload gets new keys, so the old variants stay valid until
+	// the user points at the new ones.
+	prefix := fmt.Sprintf("avatars/%d/%s", userID, uuid.NewString())
+	urls := make(map[string]string, len(avatarSizes))
+	for _, size := range avatarSizes {
+		variant, err := utils.EncodeJPEG(utils.ResizeSquare(img, size))
+		if err != nil {
+			s.deleteAvatars(ctx, urls)
+			s.logger.WithError(err).Error("failed to encode avatar in UploadAvatar func")
+			return status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+
+		url, err := s.blob.Put(ctx, fmt.Sprintf("%s/%d.jpg", prefix, size), bytes.NewReader(variant), "image/jpeg")
+		if err != nil {
+			s.deleteAvatars(ctx, urls)
+			s.logger.WithError(err).Error("failed to save avatar in UploadAvatar func")
+			return status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		urls[strconv.Itoa(size)] = url
+	}
+
+	profileImageUrl := urls[strconv.Itoa(profileImageSize)]
+	oldUrls, err := s.storage.User().UpdateAvatar(userID, profileImageUrl, urls)
+	if err != nil {
+		s.deleteAvatars(ctx, urls)
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to update avatar in UploadAvatar func")
+		return status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	s.deleteAvatars(ctx, oldUrls)
+
+	return stream.SendAndClose(&pb.UploadAvatarResponse{
+		ProfileImageUrl: profileImageUrl,
+		AvatarUrls:      urls,
+	})
+}
+
+func (s *UserService) deleteAvatars(ctx context.Context, urls map[string]string) {
+	for _, url := range urls {
+		if err := s.blob.Delete(ctx, url); err != nil {
+			s.logger.WithError(err).Error("failed to delete avatar")
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type BlockService struct {
+	pb.UnimplementedBlockServiceServer
+	storage storage.StorageI
+	logger  *logrus.Logger
+}
+
+func NewBlockService(strg storage.StorageI, log *logrus.Logger) *BlockService {
+	return &BlockService{
+		storage: strg,
+		logger:  log,
+	}
+}
+
+func (s *BlockService) Block(ctx context.Context, req *pb.BlockRequest) (*emptypb.Empty, error) {
+	if req.UserId == req.TargetId {
+		return nil, status.Errorf(codes.InvalidArgument, "user can not block themselves")
+	}
+	if err := checkUserExists(s.storage, s.logger, req.TargetId, "Block"); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.Block().Block(req.UserId, req.TargetId)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "user is already blocked")
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to block user in Block func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BlockService) Unblock(ctx context.Context, req *pb.BlockRequest) (*emptypb.Empty, error) {
+	err := s.storage.Block().Unblock(req.UserId, req.TargetId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user is not blocked")
+		}
+		s.logger.WithError(err).Error("failed to unblock user in Unblock func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BlockService) ListBlocked(ctx context.Context, req *pb.ListBlocksRequest) (*pb.GetAllUsersResponse, error) {
+	users, err := s.storage.Block().ListBlocked(&repo.GetBlocksParams{
+		UserID: req.UserId,
+		Limit:  req.Limit,
+		Page:   req.Page,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list blocked users in ListBlocked func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseUserResponse(ctx, users)
+}
+
+func (s *BlockService) Mute(ctx context.Context, req *pb.BlockRequest) (*emptypb.Empty, error) {
+	if req.UserId == req.TargetId {
+		return nil, status.Errorf(codes.InvalidArgument, "user can not mute themselves")
+	}
+	if err := checkUserExists(s.storage, s.logger, req.TargetId, "Mute"); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.Block().Mute(req.UserId, req.TargetId)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "user is already muted")
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to mute user in Mute func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BlockService) Unmute(ctx context.Context, req *pb.BlockRequest) (*emptypb.Empty, error) {
+	err := s.storage.Block().Unmute(req.UserId, req.TargetId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user is not muted")
+		}
+		s.logger.WithError(err).Error("failed to unmute user in Unmute func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BlockService) ListMuted(ctx context.Context, req *pb.ListBlocksRequest) (*pb.GetAllUsersResponse, error) {
+	users, err := s.storage.Block().ListMuted(&repo.GetBlocksParams{
+		UserID: req.UserId,
+		Limit:  req.Limit,
+		Page:   req.Page,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list muted users in ListMuted func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseUserResponse(ctx, users)
+}
+
+func (s *BlockService) IsBlocked(ctx context.Context, req *pb.IsBlockedRequest) (*pb.IsBlockedResponse, error) {
+	isBlocked, err := s.storage.Block().IsBlocked(req.A, req.B)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check block in IsBlocked func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &pb.IsBlockedResponse{
+		IsBlocked: isBlocked,
+	}, nil
+}
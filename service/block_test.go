@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlockDeletedUser(t *testing.T) {
+	strg := newFakeStorage()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s := NewBlockService(strg, log)
+
+	deletedAt := time.Now()
+	user := testUser()
+	user.DeletedAt = &deletedAt
+	strg.users.users[user.ID] = user
+
+	req := &pb.BlockRequest{UserId: 8, TargetId: user.ID}
+	_, err := s.Block(context.Background(), req)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.Mute(context.Background(), req)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Caller is the user making the request, taken from the authorization
+// metadata. Requests without a token have no caller.
+type Caller struct {
+	UserID   int64
+	UserType string
+	// PersonalAccessToken is set when the caller authenticated with a
+	// personal access token, it can only do what Scopes allow
+	PersonalAccessToken bool
+	Scopes              []*repo.TokenScope
+}
+
+// IsAdmin reports whether the caller is an admin allowed to read users
+func (c *Caller) IsAdmin() bool {
+	return c.Can("users", "get") && c.UserType == repo.UserTypeSuperadmin
+}
+
+// Is reports whether the caller is the user and allowed to read it
+func (c *Caller) Is(userID int64) bool {
+	return c.Can("users", "get") && c.UserID == userID
+}
+
+// Can reports whether the token of the caller has the scope, session tokens
+// have every scope
+func (c *Caller) Can(resource, action string) bool {
+	if c == nil {
+		return false
+	}
+	return !c.PersonalAccessToken || hasScope(c.Scopes, resource, action)
+}
+
+type callerKey struct{}
+
+func withCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// callerFromContext returns nil for anonymous requests
+func callerFromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(callerKey{}).(*Caller)
+	return caller
+}
+
+// bearerToken returns the token of the authorization metadata, with or
+// without the Bearer prefix
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := firstMetadataValue(md, "authorization")
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = token[7:]
+	}
+	return strings.TrimSpace(token)
+}
+
+// CallerInterceptor puts the caller into the context of every request
+// with a valid authorization token. Requests with a missing, expired or
+// revoked token are handled as anonymous, so they can only see less.
+func (s *AuthService) CallerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(s.resolveCaller(ctx), req)
+	}
+}
+
+// CallerStreamInterceptor is CallerInterceptor for streaming calls
+func (s *AuthService) CallerStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &callerStream{
+			ServerStream: ss,
+			ctx:          s.resolveCaller(ss.Context()),
+		})
+	}
+}
+
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *AuthService) resolveCaller(ctx context.Context) context.Context {
+	token := bearerToken(ctx)
+	if token == "" {
+		return ctx
+	}
+
+	payload, scopes, err := s.authenticate(token)
+	if err != nil {
+		return ctx
+	}
+
+	return withCaller(ctx, &Caller{
+		UserID:              payload.UserId,
+		UserType:            payload.UserType,
+		PersonalAccessToken: strings.HasPrefix(token, PersonalAccessTokenPrefix),
+		Scopes:              scopes,
+	})
+}
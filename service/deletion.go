@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	pbp "github.com/SaidovZohid/medium_user_service/genproto/post_service"
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/blob"
+	grpcPkg "github.com/SaidovZohid/medium_user_service/pkg/grpc_client"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	DeletionStepPosts    = "delete_posts"
+	DeletionStepComments = "delete_comments"
+	DeletionStepLikes    = "delete_likes"
+	DeletionStepUser     = "purge_user"
+)
+
+// deletionSteps run in this order, the user is purged last so that a failing
+// step can be retried with the id of the user.
+var deletionSteps = []string{
+	DeletionStepPosts,
+	DeletionStepComments,
+	DeletionStepLikes,
+	DeletionStepUser,
+}
+
+// errDeletionStepUnsupported is returned by the steps the post service can
+// not do yet: it can not list the comments of a user and has no way to
+// delete likes. The steps are recorded as skipped, so the status of the
+// deletion shows what was kept.
+var errDeletionStepUnsupported = errors.New("not supported by the post service")
+
+const (
+	// deletionBatchSize is how many pending deletions are run in one pass
+	deletionBatchSize = 100
+	// deletionLease is how long other instances skip the deletions one
+	// instance has claimed, it has to be longer than a pass takes
+	deletionLease = 10 * time.Minute
+	// a failed deletion is retried after deletionBaseRetryDelay, doubled with
+	// every attempt until deletionMaxRetryDelay, so that failing deletions
+	// do not hold up newer ones
+	deletionBaseRetryDelay = time.Minute
+	deletionMaxRetryDelay  = 6 * time.Hour
+)
+
+// DeleteAccountAttempt is the scope of the password attempts of DeleteAccount
+const DeleteAccountAttempt = "delete_account"
+
+// DeleteAccount deletes the account of the user after the password is
+// confirmed. Unlike Delete it can not be undone, the posts of the user and
+// then the account are erased in the background.
+func (s *UserService) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*emptypb.Empty, error) {
+	user, err := s.storage.User().Get(req.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in DeleteAccount func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	key := strconv.FormatInt(user.ID, 10)
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	if err := s.checkAttempts(DeleteAccountAttempt, key, ipAddress); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+		s.recordFailedAttempt(DeleteAccountAttempt, key, ipAddress)
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect_password")
+	}
+	s.resetAttempts(DeleteAccountAttempt, key)
+
+	err = s.storage.AccountDeletion().Create(user.ID, deletionSteps)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		if !errors.Is(err, repo.ErrAlreadyExists) {
+			s.logger.WithError(err).Error("failed to create account deletion in DeleteAccount func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+	}
+
+	err = revokeAllTokens(s.storage, s.inMemory, s.cfg, user.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to revoke tokens in DeleteAccount func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserService) GetDeletionStatus(ctx context.Context, req *pb.IdRequest) (*pb.DeletionStatus, error) {
+	caller := callerFromContext(ctx)
+	if !caller.Is(req.Id) && !caller.IsAdmin() {
+		return nil, status.Errorf(codes.PermissionDenied, "only the user can see the deletion status")
+	}
+
+	deletion, err := s.storage.AccountDeletion().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "account deletion not found")
+		}
+		s.logger.WithError(err).Error("failed to get account deletion in GetDeletionStatus func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	res := pb.DeletionStatus{
+		UserId:      deletion.UserID,
+		CreatedAt:   deletion.CreatedAt.Format(time.RFC3339),
+		CompletedAt: formatTime(deletion.CompletedAt),
+	}
+	for _, step := range deletion.Steps {
+		res.Steps = append(res.Steps, &pb.DeletionStep{
+			Name:      step.Name,
+			Status:    step.Status,
+			Attempts:  step.Attempts,
+			LastError: step.LastError,
+			UpdatedAt: step.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &res, nil
+}
+
+// DeletionOrchestrator erases deleted accounts. It starts the deletion of
+// accounts whose restore window has expired and runs the pending steps of
+// every deletion. The progress is kept in the database, so the work goes on
+// after a restart; every step can safely run more than once.
+type DeletionOrchestrator struct {
+	storage    storage.StorageI
+	grpcClient grpcPkg.GrpcClientI
+	blob       blob.Storage
+	cfg        *config.Config
+	logger     *logrus.Logger
+}
+
+func NewDeletionOrchestrator(strg storage.StorageI, grpcConn grpcPkg.GrpcClientI, blobStorage blob.Storage, cfg *config.Config, log *logrus.Logger) *DeletionOrchestrator {
+	return &DeletionOrchestrator{
+		storage:    strg,
+		grpcClient: grpcConn,
+		blob:       blobStorage,
+		cfg:        cfg,
+		logger:     log,
+	}
+}
+
+// Run works through the deletions every AccountPurgeInterval until ctx is done.
+func (o *DeletionOrchestrator) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.cfg.AccountPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := o.RunOnce(ctx); err != nil {
+			o.logger.WithError(err).Error("failed to run account deletions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *DeletionOrchestrator) RunOnce(ctx context.Context) error {
+	_, err := o.storage.AccountDeletion().CreateForDeletedBefore(
+		time.Now().Add(-o.cfg.AccountRestoreWindow),
+		deletionSteps,
+	)
+	if err != nil {
+		return err
+	}
+
+	deletions, err := o.storage.AccountDeletion().GetPending(deletionBatchSize, deletionLease)
+	if err != nil {
+		return err
+	}
+
+	for _, deletion := range deletions {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		o.runDeletion(ctx, deletion)
+	}
+
+	return nil
+}
+
+// runDeletion runs the pending steps in order and stops at the first one
+// that fails, it is retried on the next run
+func (o *DeletionOrchestrator) runDeletion(ctx context.Context, deletion *repo.AccountDeletion) {
+	logger := o.logger.WithField("user_id", deletion.UserID)
+
+	var skipped []string
+	for _, step := range deletion.Steps {
+		if step.Status == repo.DeletionStepSkipped {
+			skipped = append(skipped, step.Name)
+		}
+		if step.Status != repo.DeletionStepPending {
+			continue
+		}
+
+		err := o.runStep(ctx, deletion.UserID, step.Name)
+		if errors.Is(err, errDeletionStepUnsupported) {
+			if err := o.storage.AccountDeletion().SkipStep(deletion.UserID, step.Name, err.Error()); err != nil {
+				logger.WithError(err).Error("failed to save skipped account deletion step")
+				return
+			}
+			skipped = append(skipped, step.Name)
+			continue
+		}
+		if err != nil {
+			logger.WithError(err).Errorf("failed to run account deletion step %s", step.Name)
+			retryAt := time.Now().Add(deletionRetryDelay(step.Attempts + 1))
+			if err := o.storage.AccountDeletion().FailStep(deletion.UserID, step.Name, err.Error(), retryAt); err != nil {
+				logger.WithError(err).Error("failed to save failed account deletion step")
+			}
+			return
+		}
+
+		if err := o.storage.AccountDeletion().CompleteStep(deletion.UserID, step.Name); err != nil {
+			logger.WithError(err).Error("failed to save completed account deletion step")
+			return
+		}
+	}
+
+	if len(skipped) > 0 {
+		logger.WithField("skipped_steps", skipped).Warn("account deleted except for the skipped steps")
+		return
+	}
+	logger.Info("account deleted")
+}
+
+// deletionRetryDelay is how long a step waits after its attempts failed
+func deletionRetryDelay(attempts int32) time.Duration {
+	delay := deletionBaseRetryDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= deletionMaxRetryDelay {
+			return deletionMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+func (o *DeletionOrchestrator) runStep(ctx context.Context, userID int64, step string) error {
+	switch step {
+	case DeletionStepPosts:
+		return o.deletePosts(ctx, userID)
+	case DeletionStepComments, DeletionStepLikes:
+		return errDeletionStepUnsupported
+	case DeletionStepUser:
+		return o.purgeUser(ctx, userID)
+	}
+	return errors.New("unknown account deletion step " + step)
+}
+
+// deletePosts reads every post of the user before deleting any of them, as
+// deleting shifts the pages
+func (o *DeletionOrchestrator) deletePosts(ctx context.Context, userID int64) error {
+	var ids []int64
+	for page := int64(1); ; page++ {
+		result, err := o.grpcClient.PostService().GetAll(ctx, &pbp.GetPostsParamsReq{
+			Limit:  exportPageSize,
+			Page:   page,
+			UserId: userID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, post := range result.Posts {
+			// Only posts of the user are deleted, whatever the filter returned
+			if post.UserId == userID {
+				ids = append(ids, post.Id)
+			}
+		}
+		if len(result.Posts) < exportPageSize {
+			break
+		}
+	}
+
+	for _, id := range ids {
+		_, err := o.grpcClient.PostService().Delete(ctx, &pbp.GetPostRequest{Id: id})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *DeletionOrchestrator) purgeUser(ctx context.Context, userID int64) error {
+	user, err := o.storage.User().Purge(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// purged by an earlier run
+			return nil
+		}
+		return err
+	}
+
+	for _, url := range user.AvatarUrls {
+		err := o.blob.Delete(ctx, url)
+		if err != nil && !errors.Is(err, blob.ErrNotFound) {
+			o.logger.WithError(err).Error("failed to delete avatar of purged user")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeletionRetryDelay(t *testing.T) {
+	require.Equal(t, time.Minute, deletionRetryDelay(1))
+	require.Equal(t, 4*time.Minute, deletionRetryDelay(3))
+	require.Equal(t, deletionMaxRetryDelay, deletionRetryDelay(100))
+}
+
+func TestDeleteAccountLimitsAttempts(t *testing.T) {
+	strg := newFakeStorage()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s := NewUserService(strg, newFakeInMemory(), nil, &config.Config{}, nil, log)
+
+	password, err := utils.HashPassword("secret123")
+	require.NoError(t, err)
+	user := testUser()
+	user.Password = password
+	strg.users.users[user.ID] = user
+
+	req := &pb.DeleteAccountRequest{UserId: user.ID, Password: "wrong"}
+	_, err = s.DeleteAccount(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.DeleteAccount(context.Background(), req)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGetDeletionStatusRequiresUser(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s := NewUserService(newFakeStorage(), newFakeInMemory(), nil, &config.Config{}, nil, log)
+
+	ctx := withCaller(context.Background(), &Caller{UserID: 2, UserType: repo.UserTypeUser})
+	_, err := s.GetDeletionStatus(ctx, &pb.IdRequest{Id: 1})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	EmailChangeKey     = "email_change_"
+	EmailChangeCodeKey = "email_change_code_"
+)
+
+const (
+	EmailChangeEmail       = "email_change_email"
+	EmailChangeNoticeEmail = "email_change_notice_email"
+)
+
+const EmailChangeAttempt = "email_change"
+
+const emailChangeDuration = 10 * time.Minute
+
+func (s *AuthService) RequestEmailChange(ctx context.Context, req *pb.RequestEmailChangeRequest) (*emptypb.Empty, error) {
+	userID := strconv.FormatInt(req.UserId, 10)
+	newEmail := utils.NormalizeEmail(req.NewEmail)
+
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	if err := s.checkAttempts(EmailChangeAttempt, userID, ipAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := s.storage.User().Get(req.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in RequestEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+		s.recordFailedAttempt(EmailChangeAttempt, userID, ipAddress)
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect_password")
+	}
+	s.resetAttempts(EmailChangeAttempt, userID)
+
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, status.Errorf(codes.InvalidArgument, "new email is the same as the current one")
+	}
+	if err := s.checkEmailAvailable(newEmail); err != nil {
+		return nil, err
+	}
+
+	code, err := utils.GenerateRandomCode(6)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate code in RequestEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	err = s.inMemory.Set(EmailChangeKey+userID, newEmail, emailChangeDuration)
+	if err == nil {
+		err = s.inMemory.Set(EmailChangeCodeKey+userID, code, emailChangeDuration)
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("failed to save email change in RequestEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	go func() {
+		err := s.notifier.SendEmail(context.Background(), &Email{
+			UserID:   req.UserId,
+			Category: NotificationSecurity,
+			To:       newEmail,
+			Subject:  "Confirm your new email",
+			Body: map[string]string{
+				"code": code,
+			},
+			Type: EmailChangeEmail,
+		})
+		if err != nil {
+			s.logger.WithError(err).Error("failed to send email change code")
+		}
+	}()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*emptypb.Empty, error) {
+	userID := strconv.FormatInt(req.UserId, 10)
+
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	if err := s.checkAttempts(EmailChangeAttempt, userID, ipAddress); err != nil {
+		return nil, err
+	}
+
+	err := s.checkCode(EmailChangeCodeKey+userID, req.Code)
+	if err != nil {
+		if errors.Is(err, errCodeExpired) {
+			return nil, status.Errorf(codes.NotFound, "code_expired")
+		}
+		if errors.Is(err, errIncorrectCode) {
+			s.recordFailedAttempt(EmailChangeAttempt, userID, ipAddress)
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect_code")
+		}
+		s.logger.WithError(err).Error("failed to check code in ConfirmEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	s.resetAttempts(EmailChangeAttempt, userID)
+
+	newEmail, err := s.inMemory.GetDel(EmailChangeKey + userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "code_expired")
+		}
+		s.logger.WithError(err).Error("failed to get new email in ConfirmEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	user, err := s.storage.User().Get(req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user in ConfirmEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	// The address may have been taken since the change was requested,
+	// the unique constraint is what finally decides.
+	err = s.storage.User().UpdateEmail(req.UserId, newEmail)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "email is already taken")
+		}
+		s.logger.WithError(err).Error("failed to update email in ConfirmEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	err = revokeAllTokens(s.storage, s.inMemory, s.cfg, req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to revoke tokens in ConfirmEmailChange func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	go func() {
+		err := s.notifier.SendEmail(context.Background(), &Email{
+			UserID:   user.ID,
+			Category: NotificationSecurity,
+			To:       user.Email,
+			Subject:  "Your email has been changed",
+			Body: map[string]string{
+				"new_email": newEmail,
+			},
+			Type: EmailChangeNoticeEmail,
+		})
+		if err != nil {
+			s.logger.WithError(err).Error("failed to send email change notice")
+		}
+	}()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) checkEmailAvailable(email string) error {
+	_, err := s.storage.User().GetByEmail(email)
+	if err == nil {
+		return status.Errorf(codes.AlreadyExists, "email is already taken")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		s.logger.WithError(err).Error("failed to get user by email")
+		return status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	pbp "github.com/SaidovZohid/medium_user_service/genproto/post_service"
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exportChunkSize is the largest chunk of the archive sent in one message
+	exportChunkSize = 64 << 10
+	// exportPageSize is the page size lists are read with
+	exportPageSize = 100
+)
+
+// exportReadme explains the data the archive does not have.
+const exportReadme = `This archive has the personal data the user service keeps about you,
+every file is JSON:
+
+  profile.json                   your profile
+  sessions.json                  devices you are signed in on
+  personal_access_tokens.json    your access tokens, without the secrets
+  settings.json                  your preferences
+  notification_preferences.json  the notifications you receive
+  followers.json                 users following you
+  following.json                 users you follow
+  blocked.json                   users you blocked
+  muted.json                     users you muted
+  posts.json                     your posts
+
+Comments and likes are not included, the post service can not list them
+by author yet. No audit log is kept for your account.
+`
+
+type exportFile struct {
+	name string
+	data interface{}
+}
+
+// ExportMyData streams a ZIP archive with the personal data of the user.
+// Only the user and admins can export it.
+func (s *UserService) ExportMyData(req *pb.ExportMyDataRequest, stream pb.UserService_ExportMyDataServer) error {
+	ctx := stream.Context()
+
+	caller := callerFromContext(ctx)
+	if !caller.Is(req.UserId) && !caller.IsAdmin() {
+		return status.Errorf(codes.PermissionDenied, "only the user can export the data")
+	}
+
+	// Everything is gathered before the first chunk is sent, so a failure
+	// never leaves the client with a truncated archive.
+	files, err := s.exportFiles(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	buffer := bufio.NewWriterSize(&exportWriter{stream: stream}, exportChunkSize)
+	archive := zip.NewWriter(buffer)
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if readme, ok := file.data.(string); ok {
+			_, err = w.Write([]byte(readme))
+		} else {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(file.data)
+		}
+		if err != nil {
+			s.logger.WithError(err).Error("failed to write export in ExportMyData func")
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return buffer.Flush()
+}
+
+func (s *UserService) exportFiles(ctx context.Context, userID int64) ([]exportFile, error) {
+	user, err := s.storage.User().Get(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in ExportMyData func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	profile := projectUser(&Caller{UserID: user.ID, UserType: user.Type}, user)
+
+	sessions, err := s.storage.Session().GetAllByUser(userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get sessions in ExportMyData func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	var exportedSessions []*pb.Session
+	for _, session := range sessions {
+		exportedSessions = append(exportedSessions, &pb.Session{
+			Id:         session.ID,
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IpAddress,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+		})
+	}
+
+	tokens, err := s.storage.PersonalAccessToken().GetAllByUser(userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get personal access tokens in ExportMyData func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	var exportedTokens []*pb.PersonalAccessToken
+	for _, token := range tokens {
+		exportedTokens = append(exportedTokens, parsePersonalAccessToken(token))
+	}
+
+	settings, err := s.storage.Settings().Get(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		settings = &repo.UserSettings{UserID: userID, SchemaVersion: settingsSchemaVersion}
+	} else if err != nil {
+		s.logger.WithError(err).Error("failed to get settings in ExportMyData func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	preferences, err := s.storage.NotificationPreference().GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get notification preferences in ExportMyData func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	files := []exportFile{
+		{name: "README.txt", data: exportReadme},
+		{name: "profile.json", data: profile},
+		{name: "sessions.json", data: exportedSessions},
+		{name: "personal_access_tokens.json", data: exportedTokens},
+		{name: "settings.json", data: parseSettings(settings)},
+		{name: "notification_preferences.json", data: parseNotificationPreferences(userID, preferences)},
+	}
+
+	lists := []struct {
+		name string
+		list func(page int32) (*repo.GetAllUsersResult, error)
+	}{
+		{"followers.json", func(page int32) (*repo.GetAllUsersResult, error) {
+			return s.storage.Follow().ListFollowers(&repo.GetFollowsParams{UserID: userID, Limit: exportPageSize, Page: page})
+		}},
+		{"following.json", func(page int32) (*repo.GetAllUsersResult, error) {
+			return s.storage.Follow().ListFollowing(&repo.GetFollowsParams{UserID: userID, Limit: exportPageSize, Page: page})
+		}},
+		{"blocked.json", func(page int32) (*repo.GetAllUsersResult, error) {
+			return s.storage.Block().ListBlocked(&repo.GetBlocksParams{UserID: userID, Limit: exportPageSize, Page: page})
+		}},
+		{"muted.json", func(page int32) (*repo.GetAllUsersResult, error) {
+			return s.storage.Block().ListMuted(&repo.GetBlocksParams{UserID: userID, Limit: exportPageSize, Page: page})
+		}},
+	}
+	for _, l := range lists {
+		users, err := exportUsers(l.list)
+		if err != nil {
+			s.logger.WithError(err).Errorf("failed to list %s in ExportMyData func", l.name)
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		files = append(files, exportFile{name: l.name, data: users})
+	}
+
+	posts, err := s.exportPosts(ctx, userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get posts in ExportMyData func")
+		return nil, status.Errorf(codes.Unavailable, "failed to get posts: %v", err)
+	}
+	files = append(files, exportFile{name: "posts.json", data: posts})
+
+	return files, nil
+}
+
+// exportUsers reads every page of list, the users are projected as other
+// users see them
+func exportUsers(list func(page int32) (*repo.GetAllUsersResult, error)) ([]*pb.User, error) {
+	var users []*pb.User
+	for page := int32(1); ; page++ {
+		result, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range result.Users {
+			users = append(users, projectUser(nil, user))
+		}
+		if len(result.Users) < exportPageSize {
+			return users, nil
+		}
+	}
+}
+
+func (s *UserService) exportPosts(ctx context.Context, userID int64) ([]*pbp.Post, error) {
+	var posts []*pbp.Post
+	for page := int64(1); ; page++ {
+		result, err := s.grpcClient.PostService().GetAll(ctx, &pbp.GetPostsParamsReq{
+			Limit:  exportPageSize,
+			Page:   page,
+			UserId: userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, result.Posts...)
+		if len(result.Posts) < exportPageSize || int64(len(posts)) >= result.Count {
+			return posts, nil
+		}
+	}
+}
+
+// exportWriter sends what is written to it as chunks of the stream
+type exportWriter struct {
+	stream pb.UserService_ExportMyDataServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > exportChunkSize {
+			n = exportChunkSize
+		}
+		if err := w.stream.Send(&pb.ExportMyDataResponse{Chunk: p[:n]}); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
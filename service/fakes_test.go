@@ -0,0 +1,207 @@
+package service
+
+import (
+	"database/sql"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+)
+
+// The fakes implement only what the tests call, any other method panics
+// on the embedded nil interface.
+
+type fakeStorage struct {
+	storage.StorageI
+	users      *fakeUsers
+	mfa        *fakeMFA
+	tokens     *fakePersonalAccessTokens
+	identities *fakeIdentities
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{
+		users:      &fakeUsers{users: map[int64]*repo.User{}},
+		mfa:        &fakeMFA{mfa: map[int64]*repo.MFA{}},
+		tokens:     &fakePersonalAccessTokens{},
+		identities: &fakeIdentities{identities: map[string]*repo.Identity{}},
+	}
+}
+
+func (s *fakeStorage) User() repo.UserStorageI { return s.users }
+func (s *fakeStorage) MFA() repo.MFAStorageI   { return s.mfa }
+func (s *fakeStorage) Identity() repo.IdentityStorageI {
+	return s.identities
+}
+func (s *fakeStorage) PersonalAccessToken() repo.PersonalAccessTokenStorageI {
+	return s.tokens
+}
+
+type fakeUsers struct {
+	repo.UserStorageI
+	users map[int64]*repo.User
+}
+
+func (f *fakeUsers) Get(userID int64) (*repo.User, error) {
+	user, ok := f.users[userID]
+	if !ok || user.DeletedAt != nil {
+		return nil, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (f *fakeUsers) Create(u *repo.User) (*repo.User, error) {
+	for _, user := range f.users {
+		if strings.EqualFold(user.Email, u.Email) {
+			return nil, repo.ErrAlreadyExists
+		}
+	}
+	u.ID = int64(len(f.users) + 1)
+	f.users[u.ID] = u
+	return u, nil
+}
+
+func (f *fakeUsers) GetByEmail(email string) (*repo.User, error) {
+	for _, user := range f.users {
+		if user.DeletedAt == nil && strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeUsers) GetDeleted(email, phoneNumber string) (*repo.User, error) {
+	for _, user := range f.users {
+		if user.DeletedAt != nil && (email != "" && strings.EqualFold(user.Email, email) || phoneNumber != "" && user.PhoneNumber == phoneNumber) {
+			return user, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeUsers) Restore(userID int64, deletedAfter time.Time) error {
+	user, ok := f.users[userID]
+	if !ok || user.DeletedAt == nil || user.DeletedAt.Before(deletedAfter) {
+		return sql.ErrNoRows
+	}
+	user.DeletedAt = nil
+	return nil
+}
+
+type fakeIdentities struct {
+	repo.IdentityStorageI
+	// identities are keyed by provider and subject
+	identities map[string]*repo.Identity
+}
+
+func (f *fakeIdentities) Get(provider, subject string) (*repo.Identity, error) {
+	identity, ok := f.identities[provider+"_"+subject]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return identity, nil
+}
+
+type fakeMFA struct {
+	repo.MFAStorageI
+	mfa map[int64]*repo.MFA
+}
+
+func (f *fakeMFA) Get(userID int64) (*repo.MFA, error) {
+	mfa, ok := f.mfa[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return mfa, nil
+}
+
+type fakePersonalAccessTokens struct {
+	repo.PersonalAccessTokenStorageI
+	tokens      []*repo.PersonalAccessToken
+	lastUsedSet int
+}
+
+func (f *fakePersonalAccessTokens) GetByHash(tokenHash string) (*repo.PersonalAccessToken, error) {
+	for _, token := range f.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakePersonalAccessTokens) UpdateLastUsed(id int64) error {
+	f.lastUsedSet++
+	return nil
+}
+
+type fakeInMemory struct {
+	storage.InMemoryStorageI
+	values map[string]string
+}
+
+func newFakeInMemory() *fakeInMemory {
+	return &fakeInMemory{values: map[string]string{}}
+}
+
+func (f *fakeInMemory) Set(key, value string, exp time.Duration) error {
+	f.values[key] = value
+	return nil
+}
+
+func (f *fakeInMemory) SetNX(key, value string, exp time.Duration) (bool, error) {
+	if _, ok := f.values[key]; ok {
+		return false, nil
+	}
+	f.values[key] = value
+	return true, nil
+}
+
+func (f *fakeInMemory) Get(key string) (string, error) {
+	value, ok := f.values[key]
+	if !ok {
+		return "", storage.ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (f *fakeInMemory) GetDel(key string) (string, error) {
+	value, err := f.Get(key)
+	delete(f.values, key)
+	return value, err
+}
+
+func (f *fakeInMemory) Delete(keys ...string) error {
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return nil
+}
+
+func (f *fakeInMemory) Incr(key string, exp time.Duration) (int64, error) {
+	n, _ := strconv.ParseInt(f.values[key], 10, 64)
+	f.values[key] = strconv.FormatInt(n+1, 10)
+	return n + 1, nil
+}
+
+func (f *fakeInMemory) AddToWindow(key string, window time.Duration) (int64, error) {
+	return f.Incr(key, window)
+}
+
+func (f *fakeInMemory) TTL(key string) (time.Duration, error) {
+	if _, ok := f.values[key]; !ok {
+		return 0, storage.ErrKeyNotFound
+	}
+	return time.Minute, nil
+}
+
+func newTestAuthService(strg storage.StorageI, inMemory storage.InMemoryStorageI) *AuthService {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return NewAuthService(strg, inMemory, nil, &config.Config{AccountRestoreWindow: time.Hour}, nil, log)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const NewFollowerEmail = "new_follower_email"
+
+type FollowService struct {
+	pb.UnimplementedFollowServiceServer
+	storage  storage.StorageI
+	notifier *Notifier
+	logger   *logrus.Logger
+}
+
+func NewFollowService(strg storage.StorageI, notifier *Notifier, log *logrus.Logger) *FollowService {
+	return &FollowService{
+		storage:  strg,
+		notifier: notifier,
+		logger:   log,
+	}
+}
+
+func (s *FollowService) Follow(ctx context.Context, req *pb.FollowRequest) (*emptypb.Empty, error) {
+	if req.FollowerId == req.FollowingId {
+		return nil, status.Errorf(codes.InvalidArgument, "user can not follow themselves")
+	}
+	// Deleted users can not be followed, nor be notified about it
+	if err := checkUserExists(s.storage, s.logger, req.FollowingId, "Follow"); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.Follow().Follow(req.FollowerId, req.FollowingId)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "user is already followed")
+		}
+		if errors.Is(err, repo.ErrBlocked) {
+			return nil, status.Errorf(codes.PermissionDenied, "user is blocked")
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to follow user in Follow func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	go s.sendNewFollowerEmail(req.FollowerId, req.FollowingId)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FollowService) sendNewFollowerEmail(followerID, followingID int64) {
+	follower, err := s.storage.User().Get(followerID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follower for new follower email")
+		return
+	}
+	user, err := s.storage.User().Get(followingID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user for new follower email")
+		return
+	}
+
+	err = s.notifier.SendEmail(context.Background(), &Email{
+		UserID:   user.ID,
+		Category: NotificationSocial,
+		To:       user.Email,
+		Subject:  "You have a new follower",
+		Body: map[string]string{
+			"name":          user.FirstName,
+			"follower_id":   strconv.FormatInt(follower.ID, 10),
+			"follower_name": follower.FirstName + " " + follower.LastName,
+			"username":      follower.Username,
+		},
+		Type: NewFollowerEmail,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to send new follower email")
+	}
+}
+
+func (s *FollowService) Unfollow(ctx context.Context, req *pb.FollowRequest) (*emptypb.Empty, error) {
+	err := s.storage.Follow().Unfollow(req.FollowerId, req.FollowingId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user is not followed")
+		}
+		s.logger.WithError(err).Error("failed to unfollow user in Unfollow func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FollowService) ListFollowers(ctx context.Context, req *pb.ListFollowsRequest) (*pb.GetAllUsersResponse, error) {
+	users, err := s.storage.Follow().ListFollowers(&repo.GetFollowsParams{
+		UserID: req.UserId,
+		Limit:  req.Limit,
+		Page:   req.Page,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list followers in ListFollowers func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return s.parseUsers(ctx, users)
+}
+
+func (s *FollowService) ListFollowing(ctx context.Context, req *pb.ListFollowsRequest) (*pb.GetAllUsersResponse, error) {
+	users, err := s.storage.Follow().ListFollowing(&repo.GetFollowsParams{
+		UserID: req.UserId,
+		Limit:  req.Limit,
+		Page:   req.Page,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list following in ListFollowing func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return s.parseUsers(ctx, users)
+}
+
+func (s *FollowService) parseUsers(ctx context.Context, users *repo.GetAllUsersResult) (*pb.GetAllUsersResponse, error) {
+	res, err := parseUserResponse(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setFollowCounts(s.storage, res.Users...)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
+
+func (s *FollowService) IsFollowing(ctx context.Context, req *pb.FollowRequest) (*pb.IsFollowingResponse, error) {
+	isFollowing, err := s.storage.Follow().IsFollowing(req.FollowerId, req.FollowingId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check follow in IsFollowing func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &pb.IsFollowingResponse{
+		IsFollowing: isFollowing,
+	}, nil
+}
+
+func (s *FollowService) GetFollowCounts(ctx context.Context, req *pb.IdRequest) (*pb.FollowCountsResponse, error) {
+	counts, err := s.storage.Follow().GetCounts(req.Id)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts in GetFollowCounts func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &pb.FollowCountsResponse{
+		FollowersCount: counts[req.Id].Followers,
+		FollowingCount: counts[req.Id].Following,
+	}, nil
+}
+
+// setFollowCounts fills the follower and following counts of the users
+func setFollowCounts(strg storage.StorageI, users ...*pb.User) error {
+	ids := make([]int64, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+
+	counts, err := strg.Follow().GetCounts(ids...)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if c, ok := counts[user.Id]; ok {
+			user.FollowersCount = c.Followers
+			user.FollowingCount = c.Following
+		}
+	}
+
+	return nil
+}
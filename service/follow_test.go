@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFollowDeletedUser(t *testing.T) {
+	strg := newFakeStorage()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s := NewFollowService(strg, nil, log)
+
+	deletedAt := time.Now()
+	user := testUser()
+	user.DeletedAt = &deletedAt
+	strg.users.users[user.ID] = user
+
+	_, err := s.Follow(context.Background(), &pb.FollowRequest{FollowerId: 8, FollowingId: user.ID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
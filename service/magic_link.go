@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	MagicLinkKey         = "magic_link_"
+	MagicLinkRequestsKey = "magic_link_requests_"
+)
+
+const MagicLinkEmail = "magic_link_email"
+
+const (
+	magicLinkDuration = 15 * time.Minute
+	// maxMagicLinkRequests emails are sent to one address within attemptsWindow
+	maxMagicLinkRequests = 5
+)
+
+// RequestMagicLink emails a single-use login link. It succeeds for unknown
+// emails as well, so it can not be used to find out who has an account.
+func (s *AuthService) RequestMagicLink(ctx context.Context, req *pb.RequestMagicLinkRequest) (*emptypb.Empty, error) {
+	email := utils.NormalizeEmail(req.Email)
+
+	requests, err := s.inMemory.Incr(MagicLinkRequestsKey+email, attemptsWindow)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to count magic link requests")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if requests > maxMagicLinkRequests {
+		ttl, err := s.inMemory.TTL(MagicLinkRequestsKey + email)
+		if err != nil {
+			ttl = attemptsWindow
+		}
+		return nil, tooManyAttempts(ttl)
+	}
+
+	_, err = s.storage.User().GetByEmail(email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &emptypb.Empty{}, nil
+		}
+		s.logger.WithError(err).Error("failed to get user by email in RequestMagicLink func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	token, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate magic link token")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	err = s.inMemory.Set(MagicLinkKey+utils.HashOpaqueToken(token), email, magicLinkDuration)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to save magic link token")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	go func() {
+		err := s.notifier.SendEmail(context.Background(), &Email{
+			Category: NotificationSecurity,
+			To:       email,
+			Subject:  "Your login link",
+			Body: map[string]string{
+				"token": token,
+				"link":  s.cfg.MagicLinkURL + token,
+			},
+			Type: MagicLinkEmail,
+		})
+		if err != nil {
+			s.logger.WithError(err).Error("failed to send magic link email")
+		}
+	}()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) ConsumeMagicLink(ctx context.Context, req *pb.ConsumeMagicLinkRequest) (*pb.AuthResponse, error) {
+	email, err := s.inMemory.GetDel(MagicLinkKey + utils.HashOpaqueToken(req.Token))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, "magic link is invalid or expired")
+		}
+		s.logger.WithError(err).Error("failed to get magic link token")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	user, err := s.storage.User().GetByEmail(email)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user by email in ConsumeMagicLink func")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	challenge, err := s.mfaChallenge(user)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create mfa challenge in ConsumeMagicLink func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if challenge != nil {
+		return challenge, nil
+	}
+
+	res, err := s.newAuthResponse(ctx, user, "")
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create tokens in ConsumeMagicLink func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientInfo returns the user agent and ip address of the caller. The
+// address forwarded in metadata is only used when the grpc peer is one of
+// the trusted proxies, such as the gateway, anyone else could forge it.
+func clientInfo(ctx context.Context, trustedProxies []string) (userAgent, ipAddress string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	userAgent = firstMetadataValue(md, "grpcgateway-user-agent", "user-agent")
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ipAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+			ipAddress = host
+		}
+	}
+	if !isTrustedProxy(ipAddress, trustedProxies) {
+		return userAgent, ipAddress
+	}
+
+	// The client is the last address that is not a trusted proxy, the ones
+	// before it could have been sent by the client itself
+	if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+		addresses := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(addresses) - 1; i >= 0; i-- {
+			address := strings.TrimSpace(addresses[i])
+			if address == "" {
+				continue
+			}
+			ipAddress = address
+			if !isTrustedProxy(address, trustedProxies) {
+				break
+			}
+		}
+	} else if realIP := firstMetadataValue(md, "x-real-ip"); realIP != "" {
+		ipAddress = realIP
+	}
+
+	return userAgent, ipAddress
+}
+
+// isTrustedProxy reports whether the ip is one of the proxies, given as ip
+// addresses or CIDR ranges
+func isTrustedProxy(ipAddress string, proxies []string) bool {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if ip.Equal(net.ParseIP(proxy)) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstMetadataValue(md metadata.MD, keys ...string) string {
+	for _, key := range keys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientInfo(t *testing.T) {
+	trustedProxies := []string{"10.0.0.0/8", "192.168.1.1"}
+
+	testCases := []struct {
+		name      string
+		peer      string
+		forwarded string
+		expected  string
+	}{
+		{"direct", "203.0.113.7", "", "203.0.113.7"},
+		{"forged by a client", "203.0.113.7", "198.51.100.1", "203.0.113.7"},
+		{"through a trusted proxy", "10.1.2.3", "198.51.100.1", "198.51.100.1"},
+		{"prepended by a client", "10.1.2.3", "1.1.1.1, 198.51.100.1", "198.51.100.1"},
+		{"through two trusted proxies", "192.168.1.1", "198.51.100.1, 10.1.2.3", "198.51.100.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(tc.peer), Port: 50000},
+			})
+			if tc.forwarded != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", tc.forwarded))
+			}
+
+			_, ipAddress := clientInfo(ctx, trustedProxies)
+			require.Equal(t, tc.expected, ipAddress)
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	MFAChallengeKey = "mfa_challenge_"
+	MFAUsedCodeKey  = "mfa_used_code_"
+	// MFARestoreKey marks a challenge of RestoreAccount, the account is
+	// restored once it is passed
+	MFARestoreKey = "mfa_restore_"
+)
+
+const MFAAttempt = "mfa"
+
+const (
+	mfaIssuer            = "Medium"
+	mfaChallengeDuration = 5 * time.Minute
+	recoveryCodesCount   = 10
+)
+
+func (s *AuthService) EnrollMFA(ctx context.Context, req *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+	user, err := s.storage.User().Get(req.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in EnrollMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate totp secret in EnrollMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	err = s.storage.MFA().SaveSecret(user.ID, secret)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+		}
+		s.logger.WithError(err).Error("failed to save totp secret in EnrollMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &pb.EnrollMFAResponse{
+		Secret:     secret,
+		OtpauthUri: utils.TOTPURI(mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *AuthService) ConfirmMFA(ctx context.Context, req *pb.ConfirmMFARequest) (*pb.RecoveryCodesResponse, error) {
+	mfa, err := s.getMFA(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.EnabledAt != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	if err := s.checkMFAAttempts(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	if !utils.ValidateTOTP(mfa.Secret, req.Code, time.Now()) {
+		s.recordMFAFailure(ctx, req.UserId)
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect_code")
+	}
+	s.resetAttempts(MFAAttempt, strconv.FormatInt(req.UserId, 10))
+
+	err = s.storage.MFA().Enable(req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to enable mfa in ConfirmMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return s.newRecoveryCodes(req.UserId)
+}
+
+func (s *AuthService) DisableMFA(ctx context.Context, req *pb.DisableMFARequest) (*emptypb.Empty, error) {
+	user, err := s.storage.User().Get(req.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in DisableMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	mfa, err := s.getEnabledMFA(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMFAAttempts(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+		s.recordMFAFailure(ctx, req.UserId)
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect_password")
+	}
+	ok, err := s.checkMFACode(mfa, req.Code)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check mfa code in DisableMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if !ok {
+		s.recordMFAFailure(ctx, req.UserId)
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect_code")
+	}
+	s.resetAttempts(MFAAttempt, strconv.FormatInt(req.UserId, 10))
+
+	err = s.storage.MFA().Delete(req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to delete mfa in DisableMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) GenerateRecoveryCodes(ctx context.Context, req *pb.GenerateRecoveryCodesRequest) (*pb.RecoveryCodesResponse, error) {
+	mfa, err := s.getEnabledMFA(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMFAAttempts(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	if !utils.ValidateTOTP(mfa.Secret, req.Code, time.Now()) {
+		s.recordMFAFailure(ctx, req.UserId)
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect_code")
+	}
+	s.resetAttempts(MFAAttempt, strconv.FormatInt(req.UserId, 10))
+
+	return s.newRecoveryCodes(req.UserId)
+}
+
+func (s *AuthService) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.AuthResponse, error) {
+	challengeKey := MFAChallengeKey + utils.HashOpaqueToken(req.MfaToken)
+
+	value, err := s.inMemory.Get(challengeKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, "mfa token is expired")
+		}
+		s.logger.WithError(err).Error("failed to get mfa challenge in VerifyMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to parse mfa challenge in VerifyMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	if err := s.checkMFAAttempts(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.getEnabledMFA(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.checkMFACode(mfa, req.Code)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check mfa code in VerifyMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if !ok {
+		s.recordMFAFailure(ctx, userID)
+
+		attempts, err := s.inMemory.Incr(CodeAttemptsKey+challengeKey, mfaChallengeDuration)
+		if err == nil && attempts >= maxCodeAttempts {
+			err = s.inMemory.Delete(challengeKey, CodeAttemptsKey+challengeKey)
+		}
+		if err != nil {
+			s.logger.WithError(err).Error("failed to count mfa attempts in VerifyMFA func")
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect_code")
+	}
+	s.resetAttempts(MFAAttempt, strconv.FormatInt(userID, 10))
+
+	if err := s.inMemory.Delete(challengeKey, CodeAttemptsKey+challengeKey); err != nil {
+		s.logger.WithError(err).Error("failed to delete mfa challenge in VerifyMFA func")
+	}
+
+	restoreKey := MFARestoreKey + utils.HashOpaqueToken(req.MfaToken)
+	_, err = s.inMemory.GetDel(restoreKey)
+	if err == nil {
+		if err := s.restoreAccount(userID, "VerifyMFA"); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, storage.ErrKeyNotFound) {
+		s.logger.WithError(err).Error("failed to get mfa restore in VerifyMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	user, err := s.storage.User().Get(userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user in VerifyMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	res, err := s.newAuthResponse(ctx, user, "")
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create tokens in VerifyMFA func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
+
+// mfaChallenge reports whether the user has to pass a second factor after
+// the password and if so returns the challenge the client has to answer
+// with VerifyMFA.
+func (s *AuthService) mfaChallenge(user *repo.User) (*pb.AuthResponse, error) {
+	mfa, err := s.storage.MFA().Get(user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if mfa.EnabledAt == nil {
+		return nil, nil
+	}
+
+	token, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.inMemory.Set(
+		MFAChallengeKey+utils.HashOpaqueToken(token),
+		strconv.FormatInt(user.ID, 10),
+		mfaChallengeDuration,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthResponse{
+		Id:          user.ID,
+		MfaRequired: true,
+		MfaToken:    token,
+	}, nil
+}
+
+// checkMFACode accepts either a totp code or an unused recovery code. A
+// totp code can be used only once.
+func (s *AuthService) checkMFACode(mfa *repo.MFA, code string) (bool, error) {
+	if utils.ValidateTOTP(mfa.Secret, code, time.Now()) {
+		// the code is marked used atomically, so a replay racing the first
+		// use is rejected as well
+		usedKey := MFAUsedCodeKey + strconv.FormatInt(mfa.UserID, 10) + "_" + code
+		return s.inMemory.SetNX(usedKey, "1", 3*time.Minute)
+	}
+
+	recoveryCodes, err := s.storage.MFA().GetRecoveryCodes(mfa.UserID)
+	if err != nil {
+		return false, err
+	}
+	code = normalizeRecoveryCode(code)
+	for _, rc := range recoveryCodes {
+		if utils.CheckPassword(code, rc.CodeHash) != nil {
+			continue
+		}
+		err = s.storage.MFA().UseRecoveryCode(rc.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	return false, nil
+}
+
+func (s *AuthService) newRecoveryCodes(userID int64) (*pb.RecoveryCodesResponse, error) {
+	var (
+		res    pb.RecoveryCodesResponse
+		hashes []string
+	)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := utils.GenerateRandomCode(10)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to generate recovery code")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		hash, err := utils.HashPassword(code)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to hash recovery code")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		hashes = append(hashes, hash)
+		res.RecoveryCodes = append(res.RecoveryCodes, code[:5]+"-"+code[5:])
+	}
+
+	err := s.storage.MFA().ReplaceRecoveryCodes(userID, hashes)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to save recovery codes")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &res, nil
+}
+
+func (s *AuthService) getMFA(userID int64) (*repo.MFA, error) {
+	mfa, err := s.storage.MFA().Get(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not set up")
+		}
+		s.logger.WithError(err).Error("failed to get mfa")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return mfa, nil
+}
+
+func (s *AuthService) getEnabledMFA(userID int64) (*repo.MFA, error) {
+	mfa, err := s.getMFA(userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.EnabledAt == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	return mfa, nil
+}
+
+func (s *AuthService) checkMFAAttempts(ctx context.Context, userID int64) error {
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	return s.checkAttempts(MFAAttempt, strconv.FormatInt(userID, 10), ipAddress)
+}
+
+func (s *AuthService) recordMFAFailure(ctx context.Context, userID int64) {
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	s.recordFailedAttempt(MFAAttempt, strconv.FormatInt(userID, 10), ipAddress)
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ReplaceAll(strings.TrimSpace(code), "-", "")
+}
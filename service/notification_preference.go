@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *UserService) GetNotificationPreferences(ctx context.Context, req *pb.IdRequest) (*pb.NotificationPreferences, error) {
+	if err := checkUserExists(s.storage, s.logger, req.Id, "GetNotificationPreferences"); err != nil {
+		return nil, err
+	}
+
+	return s.notificationPreferences(req.Id, "GetNotificationPreferences")
+}
+
+func (s *UserService) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferencesRequest) (*pb.NotificationPreferences, error) {
+	var preferences []*repo.NotificationPreference
+	for _, preference := range req.Preferences {
+		if err := validateNotificationPreference(preference.Category, preference.Channel); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if isMandatoryNotification(preference.Category) && !preference.Enabled {
+			return nil, status.Errorf(codes.InvalidArgument, "%s notifications can not be disabled", preference.Category)
+		}
+		preferences = append(preferences, &repo.NotificationPreference{
+			UserID:   req.UserId,
+			Category: preference.Category,
+			Channel:  preference.Channel,
+			Enabled:  preference.Enabled,
+		})
+	}
+	if err := checkUserExists(s.storage, s.logger, req.UserId, "UpdateNotificationPreferences"); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.NotificationPreference().Set(preferences)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to set notification preferences in UpdateNotificationPreferences func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return s.notificationPreferences(req.UserId, "UpdateNotificationPreferences")
+}
+
+// Unsubscribe disables a category of notifications on every channel with
+// the token of an email, so it works without logging in.
+func (s *UserService) Unsubscribe(ctx context.Context, req *pb.UnsubscribeRequest) (*emptypb.Empty, error) {
+	if s.cfg.UnsubscribeSecret == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "unsubscribe links are disabled")
+	}
+
+	userID, category, err := utils.ParseUnsubscribeToken(s.cfg.UnsubscribeSecret, req.Token)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid unsubscribe token")
+	}
+	if err := validateNotificationPreference(category, ChannelEmail); err != nil || isMandatoryNotification(category) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid unsubscribe token")
+	}
+
+	var preferences []*repo.NotificationPreference
+	for _, channel := range notificationChannels {
+		preferences = append(preferences, &repo.NotificationPreference{
+			UserID:   userID,
+			Category: category,
+			Channel:  channel,
+			Enabled:  false,
+		})
+	}
+
+	err = s.storage.NotificationPreference().Set(preferences)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to set notification preferences in Unsubscribe func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// notificationPreferences returns every category on every channel, with the
+// defaults for the ones the user has not changed
+func (s *UserService) notificationPreferences(userID int64, funcName string) (*pb.NotificationPreferences, error) {
+	preferences, err := s.storage.NotificationPreference().GetAll(userID)
+	if err != nil {
+		s.logger.WithError(err).Errorf("failed to get notification preferences in %s func", funcName)
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseNotificationPreferences(userID, preferences), nil
+}
+
+func parseNotificationPreferences(userID int64, preferences []*repo.NotificationPreference) *pb.NotificationPreferences {
+	res := pb.NotificationPreferences{
+		UserId: userID,
+	}
+	for _, category := range notificationCategories {
+		for _, channel := range notificationChannels {
+			res.Preferences = append(res.Preferences, &pb.NotificationPreference{
+				Category:  category,
+				Channel:   channel,
+				Enabled:   notificationEnabled(preferences, category, channel),
+				Mandatory: isMandatoryNotification(category),
+			})
+		}
+	}
+
+	return &res
+}
+
+func validateNotificationPreference(category, channel string) error {
+	if !contains(notificationCategories, category) {
+		return fmt.Errorf("category must be one of %v", notificationCategories)
+	}
+	if !contains(notificationChannels, channel) {
+		return errors.New("channel must be email or sms")
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	"github.com/SaidovZohid/medium_user_service/genproto/notification_service"
+	grpcPkg "github.com/SaidovZohid/medium_user_service/pkg/grpc_client"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NotificationSecurity is mandatory, it is sent whatever the preferences are
+	NotificationSecurity  = "security"
+	NotificationSocial    = "social"
+	NotificationMarketing = "marketing"
+	NotificationDigest    = "digest"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+var (
+	notificationCategories = []string{NotificationSecurity, NotificationSocial, NotificationMarketing, NotificationDigest}
+	notificationChannels   = []string{ChannelEmail, ChannelSMS}
+)
+
+// defaultNotificationPreferences apply until the user changes them, a
+// missing channel is disabled. Marketing needs the consent of the user.
+var defaultNotificationPreferences = map[string]map[string]bool{
+	NotificationSecurity: {ChannelEmail: true, ChannelSMS: true},
+	NotificationSocial:   {ChannelEmail: true},
+	NotificationDigest:   {ChannelEmail: true},
+}
+
+func isMandatoryNotification(category string) bool {
+	return category == NotificationSecurity
+}
+
+// Email is sent to UserID, which is 0 when the address belongs to no user
+// yet, such as while registering. Only security emails can be sent then.
+type Email struct {
+	UserID   int64
+	Category string
+	To       string
+	Type     string
+	Subject  string
+	Body     map[string]string
+}
+
+type SMS struct {
+	UserID   int64
+	Category string
+	To       string
+	Type     string
+	Text     string
+}
+
+// Notifier sends every email and text message of the service. It drops the
+// ones the user has opted out of and adds an unsubscribe link to the rest,
+// unless no unsubscribe secret is configured.
+type Notifier struct {
+	storage    storage.StorageI
+	grpcClient grpcPkg.GrpcClientI
+	cfg        *config.Config
+	logger     *logrus.Logger
+}
+
+func NewNotifier(strg storage.StorageI, grpcConn grpcPkg.GrpcClientI, cfg *config.Config, log *logrus.Logger) *Notifier {
+	return &Notifier{
+		storage:    strg,
+		grpcClient: grpcConn,
+		cfg:        cfg,
+		logger:     log,
+	}
+}
+
+func (n *Notifier) SendEmail(ctx context.Context, email *Email) error {
+	allowed, err := n.allowed(email.UserID, email.Category, ChannelEmail)
+	if err != nil || !allowed {
+		return err
+	}
+
+	body := email.Body
+	if !isMandatoryNotification(email.Category) && n.cfg.UnsubscribeSecret != "" {
+		token := utils.CreateUnsubscribeToken(n.cfg.UnsubscribeSecret, email.UserID, email.Category)
+		body = make(map[string]string, len(email.Body)+2)
+		for key, value := range email.Body {
+			body[key] = value
+		}
+		body["unsubscribe_token"] = token
+		body["unsubscribe_link"] = n.cfg.UnsubscribeURL + token
+	}
+
+	_, err = n.grpcClient.NotificationService().SendEmail(ctx, &notification_service.SendEmailRequest{
+		To:      email.To,
+		Subject: email.Subject,
+		Body:    body,
+		Type:    email.Type,
+	})
+	return err
+}
+
+func (n *Notifier) SendSMS(ctx context.Context, sms *SMS) error {
+	allowed, err := n.allowed(sms.UserID, sms.Category, ChannelSMS)
+	if err != nil || !allowed {
+		return err
+	}
+
+	_, err = n.grpcClient.NotificationService().SendSMS(ctx, &notification_service.SendSMSRequest{
+		To:   sms.To,
+		Type: sms.Type,
+		Text: sms.Text,
+	})
+	return err
+}
+
+// allowed reports whether the user wants the category on the channel,
+// emails are also stopped by the never email frequency setting
+func (n *Notifier) allowed(userID int64, category, channel string) (bool, error) {
+	if isMandatoryNotification(category) {
+		return true, nil
+	}
+	if userID == 0 {
+		return false, nil
+	}
+
+	preferences, err := n.storage.NotificationPreference().GetAll(userID)
+	if err != nil {
+		return false, err
+	}
+	if !notificationEnabled(preferences, category, channel) {
+		n.logger.WithField("user_id", userID).Debugf("%s %s notification is disabled", category, channel)
+		return false, nil
+	}
+
+	if channel == ChannelEmail {
+		settings, err := n.storage.Settings().Get(userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, err
+		}
+		if err == nil && settings.EmailFrequency == "never" {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// notificationEnabled looks the preference up in the ones the user has
+// changed and falls back to the default
+func notificationEnabled(preferences []*repo.NotificationPreference, category, channel string) bool {
+	if isMandatoryNotification(category) {
+		return true
+	}
+	for _, preference := range preferences {
+		if preference.Category == category && preference.Channel == channel {
+			return preference.Enabled
+		}
+	}
+	return defaultNotificationPreferences[category][channel]
+}
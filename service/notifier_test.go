@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationEnabled(t *testing.T) {
+	preferences := []*repo.NotificationPreference{
+		{Category: NotificationSocial, Channel: ChannelEmail, Enabled: false},
+		{Category: NotificationMarketing, Channel: ChannelEmail, Enabled: true},
+		{Category: NotificationSecurity, Channel: ChannelEmail, Enabled: false},
+	}
+
+	require.False(t, notificationEnabled(preferences, NotificationSocial, ChannelEmail))
+	require.True(t, notificationEnabled(preferences, NotificationMarketing, ChannelEmail))
+	require.True(t, notificationEnabled(preferences, NotificationSecurity, ChannelEmail))
+
+	// defaults
+	require.True(t, notificationEnabled(nil, NotificationDigest, ChannelEmail))
+	require.False(t, notificationEnabled(nil, NotificationMarketing, ChannelEmail))
+	require.False(t, notificationEnabled(nil, NotificationSocial, ChannelSMS))
+	require.True(t, notificationEnabled(nil, NotificationSecurity, ChannelSMS))
+}
+
+func TestParseNotificationPreferences(t *testing.T) {
+	res := parseNotificationPreferences(1, nil)
+	require.Len(t, res.Preferences, len(notificationCategories)*len(notificationChannels))
+
+	for _, preference := range res.Preferences {
+		require.Equal(t, preference.Category == NotificationSecurity, preference.Mandatory)
+		require.NoError(t, validateNotificationPreference(preference.Category, preference.Channel))
+	}
+	require.Error(t, validateNotificationPreference("newsletter", ChannelEmail))
+	require.Error(t, validateNotificationPreference(NotificationSocial, "push"))
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/oauth"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *AuthService) OAuthLogin(ctx context.Context, req *pb.OAuthLoginRequest) (*pb.AuthResponse, error) {
+	provider, ok := s.oauthProviders[req.Provider]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown oauth provider: %s", req.Provider)
+	}
+
+	identity, err := provider.Exchange(ctx, req.Code, req.RedirectUri, req.CodeVerifier)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to exchange oauth code in OAuthLogin func")
+		return nil, status.Errorf(codes.Unauthenticated, "oauth login failed: %v", err)
+	}
+
+	user, err := s.userByIdentity(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.mfaChallenge(user)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create mfa challenge in OAuthLogin func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if challenge != nil {
+		return challenge, nil
+	}
+
+	res, err := s.newAuthResponse(ctx, user, "")
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create tokens in OAuthLogin func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
+}
+
+// userByIdentity finds the user the identity is linked to. An identity seen
+// for the first time is linked to the user with the same verified email or
+// a new user is registered for it.
+func (s *AuthService) userByIdentity(identity *oauth.Identity) (*repo.User, error) {
+	linked, err := s.storage.Identity().Get(identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := s.storage.User().Get(linked.UserID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// the user of the identity is deleted or purged
+			return nil, status.Errorf(codes.FailedPrecondition, "account is deleted")
+		}
+		if err != nil {
+			s.logger.WithError(err).Error("failed to get linked user in OAuthLogin func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		s.logger.WithError(err).Error("failed to get identity in OAuthLogin func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, status.Errorf(codes.FailedPrecondition, "email is not verified by %s", identity.Provider)
+	}
+
+	user, err := s.storage.User().GetByEmail(identity.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		user, err = s.createOAuthUser(identity)
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			// the email belongs to a deleted account, it has to be restored
+			return nil, status.Errorf(codes.FailedPrecondition, "the account of the email is deleted")
+		}
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user for identity in OAuthLogin func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	_, err = s.storage.Identity().Create(&repo.Identity{
+		UserID:   user.ID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "identity is already linked")
+		}
+		s.logger.WithError(err).Error("failed to link identity in OAuthLogin func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return user, nil
+}
+
+// createOAuthUser registers a user without a usable password, one can be
+// set later through the forgot password flow.
+func (s *AuthService) createOAuthUser(identity *oauth.Identity) (*repo.User, error) {
+	password, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName := identity.FirstName, identity.LastName
+	if firstName == "" {
+		firstName = strings.Split(identity.Email, "@")[0]
+	}
+
+	return s.storage.User().Create(&repo.User{
+		FirstName:       truncate(firstName, 30),
+		LastName:        truncate(lastName, 30),
+		Email:           identity.Email,
+		Password:        hashedPassword,
+		ProfileImageUrl: identity.Picture,
+		Type:            repo.UserTypeUser,
+	})
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) > max {
+		return string(r[:max])
+	}
+	return s
+}
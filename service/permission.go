@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/config"
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/policy"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type PermissionService struct {
+	pb.UnimplementedPermissionServiceServer
+	storage  storage.StorageI
+	inMemory storage.InMemoryStorageI
+	cfg      *config.Config
+	logger   *logrus.Logger
+}
+
+func NewPermissionService(strg storage.StorageI, inMemory storage.InMemoryStorageI, cfg *config.Config, log *logrus.Logger) *PermissionService {
+	return &PermissionService{
+		storage:  strg,
+		inMemory: inMemory,
+		cfg:      cfg,
+		logger:   log,
+	}
+}
+
+// builtinRole reports whether the code relies on the role by name, such
+// roles can not be renamed or deleted
+func builtinRole(name string) bool {
+	return name == repo.UserTypeSuperadmin || name == repo.UserTypeUser
+}
+
+// requireRoleManager rejects callers who may not change roles and grants,
+// only admins with a session or a roles:manage token can
+func requireRoleManager(ctx context.Context) error {
+	caller := callerFromContext(ctx)
+	if caller == nil || caller.UserType != repo.UserTypeSuperadmin || !caller.Can("roles", "manage") {
+		return status.Errorf(codes.PermissionDenied, "only admins can manage roles")
+	}
+	return nil
+}
+
+func (s *PermissionService) CreateRole(ctx context.Context, req *pb.Role) (*pb.Role, error) {
+	if err := requireRoleManager(ctx); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	role, err := s.storage.Role().Create(&repo.Role{
+		Name:        name,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "role already exists")
+		}
+		s.logger.WithError(err).Error("failed to create role in CreateRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseRole(role), nil
+}
+
+func (s *PermissionService) GetRole(ctx context.Context, req *pb.IdRequest) (*pb.Role, error) {
+	role, err := s.storage.Role().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		s.logger.WithError(err).Error("failed to get role in GetRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseRole(role), nil
+}
+
+func (s *PermissionService) GetAllRoles(ctx context.Context, req *emptypb.Empty) (*pb.GetAllRolesResponse, error) {
+	roles, err := s.storage.Role().GetAll()
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get roles in GetAllRoles func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	var res pb.GetAllRolesResponse
+	for _, role := range roles {
+		res.Roles = append(res.Roles, parseRole(role))
+	}
+
+	return &res, nil
+}
+
+func (s *PermissionService) UpdateRole(ctx context.Context, req *pb.Role) (*pb.Role, error) {
+	if err := requireRoleManager(ctx); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	role, err := s.storage.Role().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		s.logger.WithError(err).Error("failed to get role in UpdateRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if builtinRole(role.Name) && role.Name != name {
+		return nil, status.Errorf(codes.FailedPrecondition, "built-in role can not be renamed")
+	}
+
+	role, err = s.storage.Role().Update(&repo.Role{
+		ID:          req.Id,
+		Name:        name,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "role already exists")
+		}
+		s.logger.WithError(err).Error("failed to update role in UpdateRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseRole(role), nil
+}
+
+func (s *PermissionService) DeleteRole(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	if err := requireRoleManager(ctx); err != nil {
+		return nil, err
+	}
+
+	role, err := s.storage.Role().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		s.logger.WithError(err).Error("failed to get role in DeleteRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if builtinRole(role.Name) {
+		return nil, status.Errorf(codes.FailedPrecondition, "built-in role can not be deleted")
+	}
+
+	err = s.storage.Role().Delete(req.Id)
+	if err != nil {
+		if errors.Is(err, repo.ErrRoleInUse) {
+			return nil, status.Errorf(codes.FailedPrecondition, "role is assigned to users")
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		s.logger.WithError(err).Error("failed to delete role in DeleteRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *PermissionService) CreateGrant(ctx context.Context, req *pb.Grant) (*pb.Grant, error) {
+	if err := requireRoleManager(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Role == "" || req.Resource == "" || req.Action == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "role, resource and action are required")
+	}
+
+	scope, err := policy.ParseScope(req.Scope)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	grant, err := s.storage.Permission().Create(&repo.Permission{
+		UserType: req.Role,
+		Resource: req.Resource,
+		Action:   req.Action,
+		Scope:    string(scope),
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrRoleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "grant already exists")
+		}
+		s.logger.WithError(err).Error("failed to create grant in CreateGrant func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseGrant(grant), nil
+}
+
+func (s *PermissionService) GetAllGrants(ctx context.Context, req *pb.GetAllGrantsRequest) (*pb.GetAllGrantsResponse, error) {
+	grants, err := s.storage.Permission().GetAll(req.Role)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get grants in GetAllGrants func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseGrants(grants), nil
+}
+
+func (s *PermissionService) DeleteGrant(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	if err := requireRoleManager(ctx); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.Permission().Delete(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "grant not found")
+		}
+		s.logger.WithError(err).Error("failed to delete grant in DeleteGrant func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *PermissionService) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*emptypb.Empty, error) {
+	if err := requireRoleManager(ctx); err != nil {
+		return nil, err
+	}
+
+	err := s.storage.User().UpdateType(req.UserId, req.Role)
+	if err != nil {
+		if errors.Is(err, repo.ErrRoleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role not found")
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to update user type in AssignRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	// Access tokens carry the old role, the client has to refresh them.
+	err = expireAccessTokens(s.inMemory, s.cfg, req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to expire access tokens in AssignRole func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *PermissionService) GetUserPermissions(ctx context.Context, req *pb.IdRequest) (*pb.GetAllGrantsResponse, error) {
+	user, err := s.storage.User().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in GetUserPermissions func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	grants, err := s.storage.Permission().GetAll(user.Type)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get grants in GetUserPermissions func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseGrants(grants), nil
+}
+
+func parseRole(role *repo.Role) *pb.Role {
+	return &pb.Role{
+		Id:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func parseGrant(grant *repo.Permission) *pb.Grant {
+	return &pb.Grant{
+		Id:       grant.ID,
+		Role:     grant.UserType,
+		Resource: grant.Resource,
+		Action:   grant.Action,
+		Scope:    grant.Scope,
+	}
+}
+
+func parseGrants(grants []*repo.Permission) *pb.GetAllGrantsResponse {
+	var res pb.GetAllGrantsResponse
+	for _, grant := range grants {
+		res.Grants = append(res.Grants, parseGrant(grant))
+	}
+
+	return &res
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPermissionMutationsRequireAdmin(t *testing.T) {
+	s := &PermissionService{}
+
+	callers := map[string]*Caller{
+		"anonymous": nil,
+		"user":      {UserID: 7, UserType: repo.UserTypeUser},
+		"admin token": {
+			UserID:              1,
+			UserType:            repo.UserTypeSuperadmin,
+			PersonalAccessToken: true,
+			Scopes:              []*repo.TokenScope{{Resource: "users", Action: "get"}},
+		},
+	}
+
+	for name, caller := range callers {
+		t.Run(name, func(t *testing.T) {
+			ctx := withCaller(context.Background(), caller)
+
+			_, err := s.CreateRole(ctx, &pb.Role{Name: "editor"})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = s.UpdateRole(ctx, &pb.Role{Id: 3, Name: "editor"})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = s.DeleteRole(ctx, &pb.IdRequest{Id: 3})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = s.CreateGrant(ctx, &pb.Grant{Role: "editor", Resource: "posts", Action: "update"})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = s.DeleteGrant(ctx, &pb.IdRequest{Id: 3})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = s.AssignRole(ctx, &pb.AssignRoleRequest{UserId: 7, Role: repo.UserTypeSuperadmin})
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// PersonalAccessTokenPrefix marks personal access tokens so VerifyToken can
+// tell them apart from JWTs, the prefix together with the first characters
+// of the token is shown to the user to recognize it later
+const PersonalAccessTokenPrefix = "mpat_"
+
+const personalAccessTokenVisibleLength = len(PersonalAccessTokenPrefix) + 8
+
+// personalAccessTokenLastUsedInterval is how stale the last used time of a
+// token may get before it is updated
+const personalAccessTokenLastUsedInterval = time.Minute
+
+func (s *AuthService) CreatePersonalAccessToken(ctx context.Context, req *pb.CreatePersonalAccessTokenRequest) (*pb.CreatePersonalAccessTokenResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one scope is required")
+	}
+	if req.ExpiresInDays < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "expires_in_days must not be negative")
+	}
+
+	user, err := s.storage.User().Get(req.UserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user in CreatePersonalAccessToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	scopes := make([]*repo.TokenScope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		hasPermission, err := s.storage.Permission().CheckPermission(&repo.Permission{
+			UserType: user.Type,
+			Resource: scope.Resource,
+			Action:   scope.Action,
+		})
+		if err != nil {
+			s.logger.WithError(err).Error("failed to check permission in CreatePersonalAccessToken func")
+			return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+		}
+		if !hasPermission {
+			return nil, status.Errorf(codes.InvalidArgument, "scope %s:%s is not allowed", scope.Resource, scope.Action)
+		}
+		scopes = append(scopes, &repo.TokenScope{
+			Resource: scope.Resource,
+			Action:   scope.Action,
+		})
+	}
+
+	secret, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate token in CreatePersonalAccessToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	token := PersonalAccessTokenPrefix + secret
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		t := time.Now().AddDate(0, 0, int(req.ExpiresInDays))
+		expiresAt = &t
+	}
+
+	result, err := s.storage.PersonalAccessToken().Create(&repo.PersonalAccessToken{
+		UserID:    user.ID,
+		Name:      req.Name,
+		TokenHash: utils.HashOpaqueToken(token),
+		Prefix:    token[:personalAccessTokenVisibleLength],
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create personal access token in CreatePersonalAccessToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &pb.CreatePersonalAccessTokenResponse{
+		Token:               token,
+		PersonalAccessToken: parsePersonalAccessToken(result),
+	}, nil
+}
+
+func (s *AuthService) ListPersonalAccessTokens(ctx context.Context, req *pb.ListPersonalAccessTokensRequest) (*pb.ListPersonalAccessTokensResponse, error) {
+	tokens, err := s.storage.PersonalAccessToken().GetAllByUser(req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get personal access tokens in ListPersonalAccessTokens func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	var res pb.ListPersonalAccessTokensResponse
+	for _, token := range tokens {
+		res.PersonalAccessTokens = append(res.PersonalAccessTokens, parsePersonalAccessToken(token))
+	}
+
+	return &res, nil
+}
+
+func (s *AuthService) RevokePersonalAccessToken(ctx context.Context, req *pb.RevokePersonalAccessTokenRequest) (*emptypb.Empty, error) {
+	err := s.storage.PersonalAccessToken().Revoke(req.UserId, req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "personal access token not found")
+		}
+		s.logger.WithError(err).Error("failed to revoke personal access token in RevokePersonalAccessToken func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// verifyPersonalAccessToken returns the payload of a personal access token
+// and the scopes it is limited to
+func (s *AuthService) verifyPersonalAccessToken(accessToken string) (*pb.AuthPayload, []*repo.TokenScope, error) {
+	token, err := s.storage.PersonalAccessToken().GetByHash(utils.HashOpaqueToken(accessToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, status.Errorf(codes.Unauthenticated, "invalid token")
+		}
+		s.logger.WithError(err).Error("failed to get personal access token")
+		return nil, nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if token.RevokedAt != nil {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "token has been revoked")
+	}
+	if token.ExpiresAt != nil && time.Now().After(*token.ExpiresAt) {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "token has expired")
+	}
+
+	user, err := s.storage.User().Get(token.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// the owner has been deleted
+			return nil, nil, status.Errorf(codes.Unauthenticated, "invalid token")
+		}
+		s.logger.WithError(err).Error("failed to get user of personal access token")
+		return nil, nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) > personalAccessTokenLastUsedInterval {
+		err = s.storage.PersonalAccessToken().UpdateLastUsed(token.ID)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to update personal access token last used time")
+		}
+	}
+
+	return &pb.AuthPayload{
+		Id:        token.Prefix,
+		UserId:    user.ID,
+		Email:     user.Email,
+		UserType:  user.Type,
+		IssuedAt:  token.CreatedAt.Format(time.RFC3339),
+		ExpiredAt: formatTime(token.ExpiresAt),
+	}, token.Scopes, nil
+}
+
+func hasScope(scopes []*repo.TokenScope, resource, action string) bool {
+	for _, scope := range scopes {
+		if scope.Resource == resource && scope.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePersonalAccessToken(token *repo.PersonalAccessToken) *pb.PersonalAccessToken {
+	res := pb.PersonalAccessToken{
+		Id:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		ExpiresAt:  formatTime(token.ExpiresAt),
+		LastUsedAt: formatTime(token.LastUsedAt),
+		CreatedAt:  token.CreatedAt.Format(time.RFC3339),
+	}
+	for _, scope := range token.Scopes {
+		res.Scopes = append(res.Scopes, &pb.TokenScope{
+			Resource: scope.Resource,
+			Action:   scope.Action,
+		})
+	}
+
+	return &res
+}
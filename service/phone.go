@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	PhoneVerificationKey     = "phone_verification_"
+	PhoneVerificationCodeKey = "phone_verification_code_"
+)
+
+const PhoneVerificationSMS = "phone_verification_sms"
+
+const PhoneVerificationAttempt = "phone_verification"
+
+const phoneVerificationDuration = 5 * time.Minute
+
+func (s *AuthService) RequestPhoneVerification(ctx context.Context, req *pb.RequestPhoneVerificationRequest) (*emptypb.Empty, error) {
+	userID := strconv.FormatInt(req.UserId, 10)
+
+	_, ipAddress := clientInfo(ctx, s.cfg.TrustedProxies)
+	if err := s.checkAttempts(PhoneVerificationAttempt, userID, ipAddress); err != nil {
+		return nil, err
+	}
+
+	phoneNumber, err := utils.NormalizePhoneNumber(req.PhoneNumber, s.cfg.DefaultPhoneCountryCode)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid phone number")
+	}
+
+	user, err := s.storage.User().GetByPhoneNumber(phoneNumber)
+	if err == nil && user.ID != req.UserId {
+		return nil, status.Errorf(codes.AlreadyExists, "phone number is already taken")
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.logger.WithError(err).Error("failed to get user by phone number in RequestPhoneVerification func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	code, err := utils.GenerateRandomCode(6)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to generate code in RequestPhoneVerification func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	err = s.inMemory.Set(PhoneVerificationKey+userID, phoneNumber, phoneVerificationDuration)
+	if err == nil {
+		err = s.inMemory.Set(PhoneVerificationCodeKey+userID, code, phoneVerificationDuration)
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("failed to save phone verification in RequestPhoneVerification func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	// Every code sent costs money, so requests count as attempts as well.
+	s.recordFailedAttempt(PhoneVerificationAttempt, userID, ipAddress)
+
+	go func() {
+		err := s.notifier.SendSMS(context.Background(), &SMS{
+			UserID:   req.UserId,
+			Category: NotificationSecurity,
+			To:       phoneNumber,
+			Type:     PhoneVerificationSMS,
+			Text:     "Your Medium verification code: " + code,
+		})
+		if err != nil {
+			s.logger.WithError(err).Error("failed to send phone verification sms")
+		}
+	}()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) ConfirmPhoneVerification(ctx context.Context, req *pb.ConfirmPhoneVerificationRequest) (*emptypb.Empty, error) {
+	userID := strconv.FormatInt(req.UserId, 10)
+
+	err := s.checkCode(PhoneVerificationCodeKey+userID, req.Code)
+	if err != nil {
+		if errors.Is(err, errCodeExpired) {
+			return nil, status.Errorf(codes.NotFound, "code_expired")
+		}
+		if errors.Is(err, errIncorrectCode) {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect_code")
+		}
+		s.logger.WithError(err).Error("failed to check code in ConfirmPhoneVerification func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	s.resetAttempts(PhoneVerificationAttempt, userID)
+
+	phoneNumber, err := s.inMemory.GetDel(PhoneVerificationKey + userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "code_expired")
+		}
+		s.logger.WithError(err).Error("failed to get phone number in ConfirmPhoneVerification func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	err = s.storage.User().VerifyPhoneNumber(req.UserId, phoneNumber)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "phone number is already taken")
+		}
+		s.logger.WithError(err).Error("failed to verify phone number in ConfirmPhoneVerification func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// userByLogin finds the user by email or, when no email is given, by
+// verified phone number.
+func (s *AuthService) userByLogin(email, phoneNumber string) (*repo.User, error) {
+	if email != "" || phoneNumber == "" {
+		return s.storage.User().GetByEmail(email)
+	}
+
+	phoneNumber, err := utils.NormalizePhoneNumber(phoneNumber, s.cfg.DefaultPhoneCountryCode)
+	if err != nil {
+		return nil, sql.ErrNoRows
+	}
+	user, err := s.storage.User().GetByPhoneNumber(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if user.PhoneVerifiedAt == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return user, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/policy"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *AuthService) CheckPermissions(ctx context.Context, req *pb.CheckPermissionsRequest) (*pb.CheckPermissionsResponse, error) {
+	payload, scopes, err := s.authenticate(req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.rules(payload.UserType, scopes)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get rules in CheckPermissions func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	res := pb.CheckPermissionsResponse{
+		Payload:     payload,
+		Permissions: make(map[string]bool, len(req.Permissions)),
+	}
+	for _, permission := range req.Permissions {
+		resource, action, ok := strings.Cut(permission, ":")
+		if !ok || resource == "" || action == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid permission %q, expected resource:action", permission)
+		}
+		res.Permissions[permission] = policy.Evaluate(rules, policy.Request{
+			SubjectID: payload.UserId,
+			Resource:  resource,
+			Action:    action,
+			OwnerID:   resourceOwnerID(req.ResourceOwnerId, resource, req.ResourceId),
+		})
+	}
+
+	return &res, nil
+}
+
+func (s *AuthService) GetEffectivePermissions(ctx context.Context, req *pb.GetEffectivePermissionsRequest) (*pb.GetEffectivePermissionsResponse, error) {
+	payload, scopes, err := s.authenticate(req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.rules(payload.UserType, scopes)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get rules in GetEffectivePermissions func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	res := pb.GetEffectivePermissionsResponse{
+		Payload: payload,
+	}
+	for _, rule := range rules {
+		res.Grants = append(res.Grants, &pb.Grant{
+			Role:     payload.UserType,
+			Resource: rule.Resource,
+			Action:   rule.Action,
+			Scope:    string(rule.Scope),
+		})
+	}
+
+	return &res, nil
+}
+
+// authenticate verifies an access token or a personal access token. The
+// scopes of a personal access token are returned too, they are nil for
+// access tokens which may do whatever the role allows.
+func (s *AuthService) authenticate(accessToken string) (*pb.AuthPayload, []*repo.TokenScope, error) {
+	if strings.HasPrefix(accessToken, PersonalAccessTokenPrefix) {
+		return s.verifyPersonalAccessToken(accessToken)
+	}
+
+	payload, err := s.verifyAccessToken(accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &pb.AuthPayload{
+		Id:        payload.Id.String(),
+		UserId:    payload.UserID,
+		Email:     payload.Email,
+		UserType:  payload.UserType,
+		IssuedAt:  payload.IssuedAt.Format(time.RFC3339),
+		ExpiredAt: payload.ExpiredAt.Format(time.RFC3339),
+		SessionId: payload.SessionID,
+	}, nil, nil
+}
+
+// rules returns the grants of the role as policy rules, limited to scopes
+// unless they are nil
+func (s *AuthService) rules(userType string, scopes []*repo.TokenScope) ([]policy.Rule, error) {
+	grants, err := s.storage.Permission().GetAll(userType)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]policy.Rule, 0, len(grants))
+	for _, grant := range grants {
+		if scopes != nil && !hasScope(scopes, grant.Resource, grant.Action) {
+			continue
+		}
+		rules = append(rules, policy.Rule{
+			Resource: grant.Resource,
+			Action:   grant.Action,
+			Scope:    policy.Scope(grant.Scope),
+		})
+	}
+
+	return rules, nil
+}
+
+// resourceOwnerID returns the owner given by the caller, users own
+// themselves so for them the resource id is used
+func resourceOwnerID(ownerID int64, resource, resourceID string) int64 {
+	if ownerID != 0 {
+		return ownerID
+	}
+	if resource == "users" && resourceID != "" {
+		id, err := strconv.ParseInt(resourceID, 10, 64)
+		if err == nil {
+			return id
+		}
+	}
+	return 0
+}
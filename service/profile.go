@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxBioLength      = 500
+	maxHeadlineLength = 100
+	maxLocationLength = 100
+	maxPronounsLength = 30
+	maxSocialLinks    = 10
+)
+
+func (s *UserService) GetPublicProfile(ctx context.Context, req *pb.GetPublicProfileRequest) (*pb.PublicProfile, error) {
+	user, err := s.storage.User().GetByUsername(req.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		s.logger.WithError(err).Error("failed to get user by username in GetPublicProfile func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	counts, err := s.storage.Follow().GetCounts(user.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts in GetPublicProfile func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &pb.PublicProfile{
+		Id:              user.ID,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Username:        user.Username,
+		ProfileImageUrl: user.ProfileImageUrl,
+		Bio:             user.Bio,
+		Headline:        user.Headline,
+		Website:         user.Website,
+		SocialLinks:     user.SocialLinks,
+		Location:        user.Location,
+		Pronouns:        user.Pronouns,
+		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
+		FollowersCount:  counts[user.ID].Followers,
+		FollowingCount:  counts[user.ID].Following,
+		AvatarUrls:      user.AvatarUrls,
+	}, nil
+}
+
+// validateProfile checks the free form profile fields before they are
+// shown to other users
+func validateProfile(user *pb.User) error {
+	if utf8.RuneCountInString(user.Bio) > maxBioLength {
+		return fmt.Errorf("bio must be at most %d characters", maxBioLength)
+	}
+	if utf8.RuneCountInString(user.Headline) > maxHeadlineLength {
+		return fmt.Errorf("headline must be at most %d characters", maxHeadlineLength)
+	}
+	if utf8.RuneCountInString(user.Location) > maxLocationLength {
+		return fmt.Errorf("location must be at most %d characters", maxLocationLength)
+	}
+	if utf8.RuneCountInString(user.Pronouns) > maxPronounsLength {
+		return fmt.Errorf("pronouns must be at most %d characters", maxPronounsLength)
+	}
+	if user.Website != "" && !isWebURL(user.Website) {
+		return fmt.Errorf("website must be an http or https url")
+	}
+	if len(user.SocialLinks) > maxSocialLinks {
+		return fmt.Errorf("at most %d social links are allowed", maxSocialLinks)
+	}
+	for network, link := range user.SocialLinks {
+		if !isWebURL(link) {
+			return fmt.Errorf("%s link must be an http or https url", network)
+		}
+	}
+	return nil
+}
+
+// isWebURL rejects javascript: and similar urls that are unsafe to render as links
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProfile(t *testing.T) {
+	require.NoError(t, validateProfile(&pb.User{
+		Bio:         "Writes about Go",
+		Website:     "https://example.com",
+		SocialLinks: map[string]string{"github": "https://github.com/medium"},
+	}))
+
+	testCases := []*pb.User{
+		{Bio: strings.Repeat("a", maxBioLength+1)},
+		{Website: "javascript:alert(1)"},
+		{Website: "example.com"},
+		{SocialLinks: map[string]string{"twitter": "ftp://twitter.com/medium"}},
+	}
+	for _, tc := range testCases {
+		require.Error(t, validateProfile(tc))
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+)
+
+// projectUser decides which fields of the user the caller may see. The
+// user and admins see everything but the password hash, which is never
+// returned. Everybody else, signed in or not, sees the public profile
+// only: no email, phone number, gender or role.
+func projectUser(caller *Caller, user *repo.User) *pb.User {
+	res := pb.User{
+		Id:              user.ID,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Username:        user.Username,
+		ProfileImageUrl: user.ProfileImageUrl,
+		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
+		Bio:             user.Bio,
+		Headline:        user.Headline,
+		Website:         user.Website,
+		SocialLinks:     user.SocialLinks,
+		Location:        user.Location,
+		Pronouns:        user.Pronouns,
+		AvatarUrls:      user.AvatarUrls,
+	}
+
+	if caller.Is(user.ID) || caller.IsAdmin() {
+		res.Email = user.Email
+		res.PhoneNumber = user.PhoneNumber
+		res.PhoneVerifiedAt = formatTime(user.PhoneVerifiedAt)
+		res.Gender = user.Gender
+		res.Type = user.Type
+	}
+
+	return &res
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func testUser() *repo.User {
+	verifiedAt := time.Now()
+	return &repo.User{
+		ID:              7,
+		FirstName:       "Zohid",
+		LastName:        "Saidov",
+		PhoneNumber:     "+998901234567",
+		Email:           "zohid@example.com",
+		Gender:          "male",
+		Password:        "$2a$10$hash",
+		Username:        "zohid",
+		Type:            repo.UserTypeUser,
+		CreatedAt:       time.Now(),
+		PhoneVerifiedAt: &verifiedAt,
+		Bio:             "Writes about Go",
+	}
+}
+
+func TestProjectUser(t *testing.T) {
+	user := testUser()
+
+	testCases := []struct {
+		name    string
+		caller  *Caller
+		private bool
+	}{
+		{"self", &Caller{UserID: user.ID, UserType: repo.UserTypeUser}, true},
+		{"admin", &Caller{UserID: 1, UserType: repo.UserTypeSuperadmin}, true},
+		{"other user", &Caller{UserID: 8, UserType: repo.UserTypeUser}, false},
+		{"token with users scope", &Caller{
+			UserID:              user.ID,
+			UserType:            repo.UserTypeUser,
+			PersonalAccessToken: true,
+			Scopes:              []*repo.TokenScope{{Resource: "users", Action: "get"}},
+		}, true},
+		{"token without users scope", &Caller{
+			UserID:              user.ID,
+			UserType:            repo.UserTypeUser,
+			PersonalAccessToken: true,
+			Scopes:              []*repo.TokenScope{{Resource: "posts", Action: "create"}},
+		}, false},
+		{"admin token without users scope", &Caller{
+			UserID:              1,
+			UserType:            repo.UserTypeSuperadmin,
+			PersonalAccessToken: true,
+		}, false},
+		{"anonymous", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := projectUser(tc.caller, user)
+
+			require.Empty(t, res.Password)
+			require.Equal(t, user.ID, res.Id)
+			require.Equal(t, user.Username, res.Username)
+			require.Equal(t, user.Bio, res.Bio)
+
+			if tc.private {
+				require.Equal(t, user.Email, res.Email)
+				require.Equal(t, user.PhoneNumber, res.PhoneNumber)
+				require.Equal(t, user.Gender, res.Gender)
+				require.Equal(t, user.Type, res.Type)
+				require.NotEmpty(t, res.PhoneVerifiedAt)
+			} else {
+				require.Empty(t, res.Email)
+				require.Empty(t, res.PhoneNumber)
+				require.Empty(t, res.Gender)
+				require.Empty(t, res.Type)
+				require.Empty(t, res.PhoneVerifiedAt)
+			}
+		})
+	}
+}
+
+func TestParseUserResponseUsesCaller(t *testing.T) {
+	users := &repo.GetAllUsersResult{Users: []*repo.User{testUser()}, Count: 1}
+
+	res, err := parseUserResponse(context.Background(), users)
+	require.NoError(t, err)
+	require.Empty(t, res.Users[0].Email)
+
+	ctx := withCaller(context.Background(), &Caller{UserID: 1, UserType: repo.UserTypeSuperadmin})
+	res, err = parseUserResponse(ctx, users)
+	require.NoError(t, err)
+	require.Equal(t, "zohid@example.com", res.Users[0].Email)
+	require.Empty(t, res.Users[0].Password)
+}
+
+type exportStream struct {
+	pb.UserService_ExportMyDataServer
+	ctx context.Context
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestExportMyDataNeedsScope(t *testing.T) {
+	s := &UserService{}
+	ctx := withCaller(context.Background(), &Caller{
+		UserID:              7,
+		UserType:            repo.UserTypeUser,
+		PersonalAccessToken: true,
+		Scopes:              []*repo.TokenScope{{Resource: "posts", Action: "create"}},
+	})
+
+	err := s.ExportMyData(&pb.ExportMyDataRequest{UserId: 7}, &exportStream{ctx: ctx})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestBearerToken(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc.def"))
+	require.Equal(t, "abc.def", bearerToken(ctx))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "abc.def"))
+	require.Equal(t, "abc.def", bearerToken(ctx))
+
+	require.Empty(t, bearerToken(context.Background()))
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *AuthService) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	sessions, err := s.storage.Session().GetAllByUser(req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get sessions in ListSessions func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	var res pb.ListSessionsResponse
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, &pb.Session{
+			Id:         session.ID,
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IpAddress,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+		})
+	}
+
+	return &res, nil
+}
+
+func (s *AuthService) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*emptypb.Empty, error) {
+	session, err := s.storage.Session().Get(req.SessionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		s.logger.WithError(err).Error("failed to get session in RevokeSession func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	if session.UserID != req.UserId {
+		return nil, status.Errorf(codes.NotFound, "session not found")
+	}
+
+	err = s.revokeSession(session.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "session already revoked")
+		}
+		s.logger.WithError(err).Error("failed to revoke session in RevokeSession func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// revokeSession signs the device out: the session is closed, its refresh
+// tokens are revoked and the last access token issued for it is rejected
+// by VerifyToken until it expires.
+func (s *AuthService) revokeSession(sessionID string) error {
+	session, err := s.storage.Session().Get(sessionID)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.Session().Revoke(sessionID)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.RefreshToken().RevokeFamily(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.inMemory.Set(RevokedTokenKey+session.TokenID, "1", s.cfg.AccessTokenDuration)
+}
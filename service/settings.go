@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/sirupsen/logrus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// settingsSchemaVersion is saved with the settings. A field added in a
+// later version is NULL in older rows, so its default applies to them.
+const settingsSchemaVersion = 1
+
+// defaultSettings apply to the fields a user has not set
+var defaultSettings = repo.UserSettings{
+	Language:              "en",
+	Timezone:              "UTC",
+	Theme:                 "system",
+	DefaultPostVisibility: "public",
+	EmailFrequency:        "immediate",
+}
+
+var (
+	settingsThemes             = []string{"system", "light", "dark"}
+	settingsPostVisibilities   = []string{"public", "followers", "private"}
+	settingsEmailFrequencies   = []string{"immediate", "daily", "weekly", "never"}
+	settingsLanguageRegexp     = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+	errSettingsUnknownTimezone = errors.New("timezone must be an IANA time zone such as Asia/Tashkent")
+	errSettingsInvalidLanguage = errors.New("language must be a language tag such as en or uz-Latn")
+)
+
+func (s *UserService) GetSettings(ctx context.Context, req *pb.IdRequest) (*pb.UserSettings, error) {
+	if err := checkUserExists(s.storage, s.logger, req.Id, "GetSettings"); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.storage.Settings().Get(req.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		settings = &repo.UserSettings{UserID: req.Id, SchemaVersion: settingsSchemaVersion}
+	} else if err != nil {
+		s.logger.WithError(err).Error("failed to get settings in GetSettings func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseSettings(settings), nil
+}
+
+func (s *UserService) UpdateSettings(ctx context.Context, req *pb.UpdateSettingsRequest) (*pb.UserSettings, error) {
+	if err := validateSettings(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := checkUserExists(s.storage, s.logger, req.UserId, "UpdateSettings"); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.storage.Settings().Update(&repo.UpdateSettingsParams{
+		UserID:                req.UserId,
+		SchemaVersion:         settingsSchemaVersion,
+		Language:              req.Language,
+		Timezone:              req.Timezone,
+		Theme:                 req.Theme,
+		DefaultPostVisibility: req.DefaultPostVisibility,
+		EmailFrequency:        req.EmailFrequency,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to update settings in UpdateSettings func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return parseSettings(settings), nil
+}
+
+// checkUserExists returns codes.NotFound for unknown and deleted users
+func checkUserExists(strg storage.StorageI, logger *logrus.Logger, userID int64, funcName string) error {
+	_, err := strg.User().Get(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "user not found")
+		}
+		logger.WithError(err).Errorf("failed to get user in %s func", funcName)
+		return status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+	return nil
+}
+
+// validateSettings checks the fields that are set, an empty value is valid
+// as it resets the field
+func validateSettings(req *pb.UpdateSettingsRequest) error {
+	if language := req.GetLanguage(); language != "" && !settingsLanguageRegexp.MatchString(language) {
+		return errSettingsInvalidLanguage
+	}
+	if timezone := req.GetTimezone(); timezone != "" {
+		if timezone == "Local" {
+			return errSettingsUnknownTimezone
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return errSettingsUnknownTimezone
+		}
+	}
+
+	options := []struct {
+		name    string
+		value   string
+		allowed []string
+	}{
+		{"theme", req.GetTheme(), settingsThemes},
+		{"default_post_visibility", req.GetDefaultPostVisibility(), settingsPostVisibilities},
+		{"email_frequency", req.GetEmailFrequency(), settingsEmailFrequencies},
+	}
+	for _, option := range options {
+		if option.value != "" && !contains(option.allowed, option.value) {
+			return fmt.Errorf("%s must be one of %v", option.name, option.allowed)
+		}
+	}
+
+	return nil
+}
+
+// parseSettings fills the fields the user has not set with the defaults
+func parseSettings(settings *repo.UserSettings) *pb.UserSettings {
+	res := pb.UserSettings{
+		UserId:                settings.UserID,
+		SchemaVersion:         settings.SchemaVersion,
+		Language:              withDefault(settings.Language, defaultSettings.Language),
+		Timezone:              withDefault(settings.Timezone, defaultSettings.Timezone),
+		Theme:                 withDefault(settings.Theme, defaultSettings.Theme),
+		DefaultPostVisibility: withDefault(settings.DefaultPostVisibility, defaultSettings.DefaultPostVisibility),
+		EmailFrequency:        withDefault(settings.EmailFrequency, defaultSettings.EmailFrequency),
+	}
+	if !settings.UpdatedAt.IsZero() {
+		res.UpdatedAt = settings.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return &res
+}
+
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
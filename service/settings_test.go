@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSettings(t *testing.T) {
+	value := func(v string) *string { return &v }
+
+	require.NoError(t, validateSettings(&pb.UpdateSettingsRequest{}))
+	require.NoError(t, validateSettings(&pb.UpdateSettingsRequest{
+		Language:              value("uz-Latn"),
+		Timezone:              value("Asia/Tashkent"),
+		Theme:                 value("dark"),
+		DefaultPostVisibility: value("followers"),
+		EmailFrequency:        value("weekly"),
+	}))
+	require.NoError(t, validateSettings(&pb.UpdateSettingsRequest{Theme: value("")}))
+
+	testCases := []*pb.UpdateSettingsRequest{
+		{Language: value("english!")},
+		{Timezone: value("Mars/Olympus")},
+		{Timezone: value("Local")},
+		{Theme: value("blue")},
+		{DefaultPostVisibility: value("everyone")},
+		{EmailFrequency: value("hourly")},
+	}
+	for _, tc := range testCases {
+		require.Error(t, validateSettings(tc))
+	}
+}
+
+func TestParseSettingsDefaults(t *testing.T) {
+	res := parseSettings(&repo.UserSettings{UserID: 1, Theme: "dark"})
+	require.Equal(t, "dark", res.Theme)
+	require.Equal(t, defaultSettings.Language, res.Language)
+	require.Equal(t, defaultSettings.Timezone, res.Timezone)
+	require.Equal(t, defaultSettings.EmailFrequency, res.EmailFrequency)
+	require.Empty(t, res.UpdatedAt)
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/SaidovZohid/medium_user_service/config"
 	pb "github.com/SaidovZohid/medium_user_service/genproto/user_service"
+	"github.com/SaidovZohid/medium_user_service/pkg/blob"
+	grpcPkg "github.com/SaidovZohid/medium_user_service/pkg/grpc_client"
 	"github.com/SaidovZohid/medium_user_service/pkg/utils"
 	"github.com/SaidovZohid/medium_user_service/storage"
 	"github.com/SaidovZohid/medium_user_service/storage/repo"
@@ -17,20 +22,39 @@ import (
 
 type UserService struct {
 	pb.UnimplementedUserServiceServer
-	storage  storage.StorageI
-	inMemory storage.InMemoryStorageI
-	logger   *logrus.Logger
+	attemptLimiter
+	storage    storage.StorageI
+	inMemory   storage.InMemoryStorageI
+	grpcClient grpcPkg.GrpcClientI
+	cfg        *config.Config
+	blob       blob.Storage
+	logger     *logrus.Logger
 }
 
-func NewUserService(strg storage.StorageI, inMemory storage.InMemoryStorageI, log *logrus.Logger) *UserService {
+func NewUserService(strg storage.StorageI, inMemory storage.InMemoryStorageI, grpcConn grpcPkg.GrpcClientI, cfg *config.Config, blobStorage blob.Storage, log *logrus.Logger) *UserService {
 	return &UserService{
-		storage:  strg,
-		inMemory: inMemory,
-		logger:   log,
+		attemptLimiter: attemptLimiter{
+			inMemory: inMemory,
+			logger:   log,
+		},
+		storage:    strg,
+		inMemory:   inMemory,
+		grpcClient: grpcConn,
+		cfg:        cfg,
+		blob:       blobStorage,
+		logger:     log,
 	}
 }
 
 func (s *UserService) Create(ctx context.Context, req *pb.User) (*pb.User, error) {
+	phoneNumber, err := s.normalizePhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid phone number")
+	}
+	if err := validateProfile(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to hash password")
@@ -39,32 +63,29 @@ func (s *UserService) Create(ctx context.Context, req *pb.User) (*pb.User, error
 	user, err := s.storage.User().Create(&repo.User{
 		FirstName:       req.FirstName,
 		LastName:        req.LastName,
-		PhoneNumber:     req.PhoneNumber,
-		Email:           req.Email,
+		PhoneNumber:     phoneNumber,
+		Email:           utils.NormalizeEmail(req.Email),
 		Gender:          req.Gender,
 		Password:        hashedPassword,
 		Username:        req.Username,
 		ProfileImageUrl: req.ProfileImageUrl,
 		Type:            req.Type,
+		Bio:             req.Bio,
+		Headline:        req.Headline,
+		Website:         req.Website,
+		SocialLinks:     req.SocialLinks,
+		Location:        req.Location,
+		Pronouns:        req.Pronouns,
 	})
 	if err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "email or username is already taken")
+		}
 		s.logger.WithError(err).Error("failed to create user")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	return &pb.User{
-		Id:              user.ID,
-		FirstName:       user.FirstName,
-		LastName:        user.LastName,
-		PhoneNumber:     user.PhoneNumber,
-		Email:           user.Email,
-		Gender:          user.Gender,
-		Password:        user.Password,
-		Username:        user.Username,
-		ProfileImageUrl: user.ProfileImageUrl,
-		Type:            user.Type,
-		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return projectUser(callerFromContext(ctx), user), nil
 }
 
 func (s *UserService) Get(ctx context.Context, req *pb.IdRequest) (*pb.User, error) {
@@ -74,19 +95,15 @@ func (s *UserService) Get(ctx context.Context, req *pb.IdRequest) (*pb.User, err
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	return &pb.User{
-		Id:              user.ID,
-		FirstName:       user.FirstName,
-		LastName:        user.LastName,
-		PhoneNumber:     user.PhoneNumber,
-		Email:           user.Email,
-		Gender:          user.Gender,
-		Password:        user.Password,
-		Username:        user.Username,
-		ProfileImageUrl: user.ProfileImageUrl,
-		Type:            user.Type,
-		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	res := projectUser(callerFromContext(ctx), user)
+
+	err = setFollowCounts(s.storage, res)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts in get func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
 }
 
 func (s *UserService) GetByEmail(ctx context.Context, req *pb.GetByEmailRequest) (*pb.User, error) {
@@ -96,22 +113,26 @@ func (s *UserService) GetByEmail(ctx context.Context, req *pb.GetByEmailRequest)
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	return &pb.User{
-		Id:              user.ID,
-		FirstName:       user.FirstName,
-		LastName:        user.LastName,
-		PhoneNumber:     user.PhoneNumber,
-		Email:           user.Email,
-		Gender:          user.Gender,
-		Password:        user.Password,
-		Username:        user.Username,
-		ProfileImageUrl: user.ProfileImageUrl,
-		Type:            user.Type,
-		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	res := projectUser(callerFromContext(ctx), user)
+
+	err = setFollowCounts(s.storage, res)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts in getbyemail func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
 }
 
 func (s *UserService) Update(ctx context.Context, req *pb.User) (*pb.User, error) {
+	phoneNumber, err := s.normalizePhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid phone number")
+	}
+	if err := validateProfile(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	user, err := s.storage.User().Update(&repo.User{
 		ID:              req.Id,
 		FirstName:       req.FirstName,
@@ -119,71 +140,101 @@ func (s *UserService) Update(ctx context.Context, req *pb.User) (*pb.User, error
 		Username:        req.Username,
 		Gender:          req.Gender,
 		Email:           req.Email,
-		PhoneNumber:     req.PhoneNumber,
+		PhoneNumber:     phoneNumber,
 		ProfileImageUrl: req.ProfileImageUrl,
 		Type:            req.Type,
+		Bio:             req.Bio,
+		Headline:        req.Headline,
+		Website:         req.Website,
+		SocialLinks:     req.SocialLinks,
+		Location:        req.Location,
+		Pronouns:        req.Pronouns,
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to update user in update func")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	return &pb.User{
-		Id:              user.ID,
-		FirstName:       user.FirstName,
-		LastName:        user.LastName,
-		PhoneNumber:     user.PhoneNumber,
-		Email:           user.Email,
-		Gender:          user.Gender,
-		Password:        user.Password,
-		Username:        user.Username,
-		ProfileImageUrl: user.ProfileImageUrl,
-		Type:            user.Type,
-		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	res := projectUser(callerFromContext(ctx), user)
+
+	err = setFollowCounts(s.storage, res)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts in update func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
 }
 
 func (s *UserService) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
 	err := s.storage.User().Delete(req.Id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
 		s.logger.WithError(err).Error("failed to delete user in delete func")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
+
+	// The account can be restored by logging in, until then nobody may use it.
+	err = revokeAllTokens(s.storage, s.inMemory, s.cfg, req.Id)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to revoke tokens in delete func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *UserService) GetAll(ctx context.Context, req *pb.GetAllUsersRequest) (*pb.GetAllUsersResponse, error) {
 	users, err := s.storage.User().GetAll(&repo.GetAllUserParams{
-		Limit:  req.Limit,
-		Page:   req.Page,
-		Search: req.Search,
+		Limit:         req.Limit,
+		Page:          req.Page,
+		Search:        req.Search,
+		SearchPrivate: callerFromContext(ctx).IsAdmin(),
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get all user in getall func")
 		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
 	}
 
-	return parseUserResponse(users)
+	res, err := parseUserResponse(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setFollowCounts(s.storage, res.Users...)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get follow counts in getall func")
+		return nil, status.Errorf(codes.Internal, "internal server error: %v", err)
+	}
+
+	return res, nil
 }
 
-func parseUserResponse(users *repo.GetAllUsersResult) (*pb.GetAllUsersResponse, error) {
+func parseUserResponse(ctx context.Context, users *repo.GetAllUsersResult) (*pb.GetAllUsersResponse, error) {
 	var res pb.GetAllUsersResponse
 	res.Count = users.Count
+	caller := callerFromContext(ctx)
 	for _, user := range users.Users {
-		u := pb.User{
-			Id:              user.ID,
-			FirstName:       user.FirstName,
-			LastName:        user.LastName,
-			Gender:          user.Gender,
-			Email:           user.Email,
-			PhoneNumber:     user.PhoneNumber,
-			Username:        user.Username,
-			ProfileImageUrl: user.ProfileImageUrl,
-			Type:            user.Type,
-			CreatedAt:       user.CreatedAt.Format(time.RFC3339),
-		}
-		res.Users = append(res.Users, &u)
+		res.Users = append(res.Users, projectUser(caller, user))
 	}
 
 	return &res, nil
 }
+
+// normalizePhoneNumber keeps an empty phone number empty
+func (s *UserService) normalizePhoneNumber(phoneNumber string) (string, error) {
+	if phoneNumber == "" {
+		return "", nil
+	}
+
+	return utils.NormalizePhoneNumber(phoneNumber, s.cfg.DefaultPhoneCountryCode)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
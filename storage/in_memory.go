@@ -2,14 +2,27 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v9"
 )
 
+var ErrKeyNotFound = errors.New("key not found")
+
 type InMemoryStorageI interface {
 	Set(key, value string, exp time.Duration) error
+	// SetNX sets the key only if it does not exist and reports whether it did
+	SetNX(key, value string, exp time.Duration) (bool, error)
 	Get(key string) (string, error)
+	GetDel(key string) (string, error)
+	Delete(keys ...string) error
+	Incr(key string, exp time.Duration) (int64, error)
+	TTL(key string) (time.Duration, error)
+	AddToWindow(key string, window time.Duration) (int64, error)
+	Publish(channel, message string) error
+	Subscribe(ctx context.Context, channel string) <-chan string
 }
 
 type storageRedis struct {
@@ -20,20 +33,127 @@ func NewInMemoryStorage(rdb *redis.Client) InMemoryStorageI {
 	return &storageRedis{
 		client: rdb,
 	}
-} 
+}
 
 func (rd *storageRedis) Set(key, value string, exp time.Duration) error {
 	err := rd.client.Set(context.Background(), key, value, exp).Err()
 	if err != nil {
 		return err
 	}
-	return nil 
+	return nil
+}
+
+func (rd *storageRedis) SetNX(key, value string, exp time.Duration) (bool, error) {
+	return rd.client.SetNX(context.Background(), key, value, exp).Result()
 }
 
 func (rd *storageRedis) Get(key string) (string, error) {
 	val, err := rd.client.Get(context.Background(), key).Result()
 	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrKeyNotFound
+		}
+		return "", err
+	}
+	return val, nil
+}
+
+// GetDel returns the value and deletes the key in one step, so the value
+// can be consumed only once.
+func (rd *storageRedis) GetDel(key string) (string, error) {
+	val, err := rd.client.GetDel(context.Background(), key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrKeyNotFound
+		}
 		return "", err
 	}
 	return val, nil
-}
\ No newline at end of file
+}
+
+func (rd *storageRedis) Delete(keys ...string) error {
+	return rd.client.Del(context.Background(), keys...).Err()
+}
+
+// Incr increments the counter stored at key. The expiration is set only
+// when the counter is created, so it counts events in a fixed window.
+func (rd *storageRedis) Incr(key string, exp time.Duration) (int64, error) {
+	ctx := context.Background()
+
+	val, err := rd.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if val == 1 {
+		if err := rd.client.Expire(ctx, key, exp).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return val, nil
+}
+
+func (rd *storageRedis) TTL(key string) (time.Duration, error) {
+	ttl, err := rd.client.TTL(context.Background(), key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, ErrKeyNotFound
+	}
+	return ttl, nil
+}
+
+// AddToWindow records an event in a sliding window kept as a sorted set
+// and returns how many events happened within the last window.
+func (rd *storageRedis) AddToWindow(key string, window time.Duration) (int64, error) {
+	ctx := context.Background()
+	now := time.Now().UnixNano()
+
+	var count *redis.IntCmd
+	_, err := rd.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now-window.Nanoseconds(), 10))
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now), Member: now})
+		count = pipe.ZCard(ctx, key)
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+func (rd *storageRedis) Publish(channel, message string) error {
+	return rd.client.Publish(context.Background(), channel, message).Err()
+}
+
+// Subscribe delivers the messages published to channel until ctx is done.
+// Messages published while the connection is being re-established are lost.
+func (rd *storageRedis) Subscribe(ctx context.Context, channel string) <-chan string {
+	messages := make(chan string)
+	pubsub := rd.client.Subscribe(ctx, channel)
+
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages
+}
@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+)
+
+const (
+	PermissionMatrixKey        = "permission_matrix_"
+	PermissionMatrixVersionKey = "permission_matrix_version"
+	PermissionsChangedChannel  = "permissions_changed"
+)
+
+const (
+	permissionMatrixTTL        = time.Hour
+	permissionMatrixVersionTTL = 30 * 24 * time.Hour
+	// A lost invalidation message can not keep stale grants for longer than this.
+	permissionMatrixLocalTTL = time.Minute
+)
+
+var (
+	permissionCacheStats       = expvar.NewMap("permission_cache")
+	permissionCacheLocalHits   = new(expvar.Int)
+	permissionCacheRedisHits   = new(expvar.Int)
+	permissionCacheDBLoads     = new(expvar.Int)
+	permissionCacheInvalidated = new(expvar.Int)
+)
+
+func init() {
+	permissionCacheStats.Set("local_hits", permissionCacheLocalHits)
+	permissionCacheStats.Set("redis_hits", permissionCacheRedisHits)
+	permissionCacheStats.Set("db_loads", permissionCacheDBLoads)
+	permissionCacheStats.Set("invalidations", permissionCacheInvalidated)
+	permissionCacheStats.Set("hit_ratio", expvar.Func(func() interface{} {
+		hits := permissionCacheLocalHits.Value() + permissionCacheRedisHits.Value()
+		total := hits + permissionCacheDBLoads.Value()
+		if total == 0 {
+			return 0.0
+		}
+		return float64(hits) / float64(total)
+	}))
+}
+
+// PermissionCache keeps the whole permission matrix in process and in Redis
+// so checking a permission does not query Postgres. Changing grants through
+// it, or roles through NewCachedStorage, invalidates every instance of the
+// service through Redis pub/sub.
+type PermissionCache struct {
+	repo.PermissionStorageI
+	inMemory InMemoryStorageI
+
+	mu         sync.RWMutex
+	grants     []*repo.Permission
+	loadedAt   time.Time
+	generation uint64
+}
+
+func NewPermissionCache(permission repo.PermissionStorageI, inMemory InMemoryStorageI) *PermissionCache {
+	return &PermissionCache{
+		PermissionStorageI: permission,
+		inMemory:           inMemory,
+	}
+}
+
+// Load fills the cache, it is meant to be called at startup
+func (c *PermissionCache) Load() error {
+	_, err := c.matrix()
+	return err
+}
+
+// Listen drops the cached matrix whenever another instance changes grants
+func (c *PermissionCache) Listen(ctx context.Context) {
+	for range c.inMemory.Subscribe(ctx, PermissionsChangedChannel) {
+		c.drop()
+	}
+}
+
+// Invalidate drops the matrix here, in Redis and in the other instances
+func (c *PermissionCache) Invalidate() error {
+	c.drop()
+	permissionCacheInvalidated.Add(1)
+
+	// Copies loaded before the change are saved under the old version and never read again.
+	_, err := c.inMemory.Incr(PermissionMatrixVersionKey, permissionMatrixVersionTTL)
+	if err != nil {
+		return err
+	}
+
+	return c.inMemory.Publish(PermissionsChangedChannel, "1")
+}
+
+func (c *PermissionCache) drop() {
+	c.mu.Lock()
+	c.grants = nil
+	c.generation++
+	c.mu.Unlock()
+}
+
+func (c *PermissionCache) CheckPermission(p *repo.Permission) (bool, error) {
+	grants, err := c.matrix()
+	if err != nil {
+		return false, err
+	}
+
+	for _, grant := range grants {
+		if grant.UserType == p.UserType && grant.Resource == p.Resource && grant.Action == p.Action {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *PermissionCache) GetAll(user_type string) ([]*repo.Permission, error) {
+	grants, err := c.matrix()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*repo.Permission, 0)
+	for _, grant := range grants {
+		if user_type == "" || grant.UserType == user_type {
+			g := *grant
+			result = append(result, &g)
+		}
+	}
+	return result, nil
+}
+
+func (c *PermissionCache) Create(p *repo.Permission) (*repo.Permission, error) {
+	result, err := c.PermissionStorageI.Create(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, c.Invalidate()
+}
+
+func (c *PermissionCache) Delete(permission_id int64) error {
+	err := c.PermissionStorageI.Delete(permission_id)
+	if err != nil {
+		return err
+	}
+
+	return c.Invalidate()
+}
+
+// matrix returns every grant of every role in the order of the database
+func (c *PermissionCache) matrix() ([]*repo.Permission, error) {
+	c.mu.RLock()
+	grants, loadedAt, generation := c.grants, c.loadedAt, c.generation
+	c.mu.RUnlock()
+
+	if grants != nil && time.Since(loadedAt) < permissionMatrixLocalTTL {
+		permissionCacheLocalHits.Add(1)
+		return grants, nil
+	}
+
+	grants, err := c.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	// An invalidation during the fetch means grants may be stale already.
+	if c.generation == generation {
+		c.grants = grants
+		c.loadedAt = time.Now()
+	}
+	c.mu.Unlock()
+
+	return grants, nil
+}
+
+// fetch reads the matrix from Redis, or from the database when Redis does
+// not have it. Redis errors are not fatal, the database is always right.
+func (c *PermissionCache) fetch() ([]*repo.Permission, error) {
+	version, err := c.inMemory.Get(PermissionMatrixVersionKey)
+	if errors.Is(err, ErrKeyNotFound) {
+		version, err = "0", nil
+	}
+
+	if err == nil {
+		data, err := c.inMemory.Get(PermissionMatrixKey + version)
+		if err == nil {
+			var grants []*repo.Permission
+			if err := json.Unmarshal([]byte(data), &grants); err == nil {
+				permissionCacheRedisHits.Add(1)
+				return grants, nil
+			}
+		}
+	}
+
+	grants, err := c.PermissionStorageI.GetAll("")
+	if err != nil {
+		return nil, err
+	}
+	permissionCacheDBLoads.Add(1)
+
+	if version != "" {
+		if data, err := json.Marshal(grants); err == nil {
+			_ = c.inMemory.Set(PermissionMatrixKey+version, string(data), permissionMatrixTTL)
+		}
+	}
+
+	return grants, nil
+}
+
+type cachedStorage struct {
+	StorageI
+	permission *PermissionCache
+	role       repo.RoleStorageI
+}
+
+// NewCachedStorage serves permissions from the cache and invalidates it
+// when roles are renamed or deleted, as that changes grants as well
+func NewCachedStorage(strg StorageI, cache *PermissionCache) StorageI {
+	return &cachedStorage{
+		StorageI:   strg,
+		permission: cache,
+		role: &cachedRoleRepo{
+			RoleStorageI: strg.Role(),
+			cache:        cache,
+		},
+	}
+}
+
+func (s *cachedStorage) Permission() repo.PermissionStorageI {
+	return s.permission
+}
+
+func (s *cachedStorage) Role() repo.RoleStorageI {
+	return s.role
+}
+
+type cachedRoleRepo struct {
+	repo.RoleStorageI
+	cache *PermissionCache
+}
+
+func (r *cachedRoleRepo) Update(role *repo.Role) (*repo.Role, error) {
+	result, err := r.RoleStorageI.Update(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, r.cache.Invalidate()
+}
+
+func (r *cachedRoleRepo) Delete(role_id int64) error {
+	err := r.RoleStorageI.Delete(role_id)
+	if err != nil {
+		return err
+	}
+
+	return r.cache.Invalidate()
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePermissionRepo struct {
+	grants []*repo.Permission
+	loads  int
+}
+
+func (f *fakePermissionRepo) CheckPermission(p *repo.Permission) (bool, error) {
+	for _, g := range f.grants {
+		if g.UserType == p.UserType && g.Resource == p.Resource && g.Action == p.Action {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakePermissionRepo) Create(p *repo.Permission) (*repo.Permission, error) {
+	p.ID = int64(len(f.grants) + 1)
+	f.grants = append(f.grants, p)
+	return p, nil
+}
+
+func (f *fakePermissionRepo) Delete(permission_id int64) error {
+	for i, g := range f.grants {
+		if g.ID == permission_id {
+			f.grants = append(f.grants[:i], f.grants[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *fakePermissionRepo) GetAll(user_type string) ([]*repo.Permission, error) {
+	f.loads++
+	result := make([]*repo.Permission, 0)
+	for _, g := range f.grants {
+		if user_type == "" || g.UserType == user_type {
+			c := *g
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+type fakeInMemory struct {
+	InMemoryStorageI
+	values    map[string]string
+	published []string
+}
+
+func (f *fakeInMemory) Get(key string) (string, error) {
+	v, ok := f.values[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	return v, nil
+}
+
+func (f *fakeInMemory) Set(key, value string, exp time.Duration) error {
+	f.values[key] = value
+	return nil
+}
+
+func (f *fakeInMemory) Incr(key string, exp time.Duration) (int64, error) {
+	f.values[key] += "1"
+	return int64(len(f.values[key])), nil
+}
+
+func (f *fakeInMemory) Publish(channel, message string) error {
+	f.published = append(f.published, channel)
+	return nil
+}
+
+func (f *fakeInMemory) Subscribe(ctx context.Context, channel string) <-chan string {
+	return make(chan string)
+}
+
+func TestPermissionCacheMatchesRepo(t *testing.T) {
+	db := &fakePermissionRepo{grants: []*repo.Permission{
+		{ID: 1, UserType: "user", Resource: "posts", Action: "create", Scope: "any"},
+		{ID: 2, UserType: "user", Resource: "posts", Action: "update", Scope: "own"},
+		{ID: 3, UserType: "superadmin", Resource: "posts", Action: "update", Scope: "any"},
+	}}
+	inMemory := &fakeInMemory{values: map[string]string{}}
+	cache := NewPermissionCache(db, inMemory)
+	require.NoError(t, cache.Load())
+
+	check := func() {
+		for _, userType := range []string{"", "user", "superadmin", "editor"} {
+			want, _ := db.GetAll(userType)
+			got, err := cache.GetAll(userType)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+
+			for _, action := range []string{"create", "update", "delete"} {
+				p := &repo.Permission{UserType: userType, Resource: "posts", Action: action}
+				want, _ := db.CheckPermission(p)
+				got, err := cache.CheckPermission(p)
+				require.NoError(t, err)
+				require.Equal(t, want, got, "%s %s", userType, action)
+			}
+		}
+	}
+	check()
+
+	_, err := cache.Create(&repo.Permission{UserType: "editor", Resource: "posts", Action: "delete", Scope: "any"})
+	require.NoError(t, err)
+	require.Equal(t, []string{PermissionsChangedChannel}, inMemory.published)
+	check()
+
+	require.NoError(t, cache.Delete(2))
+	check()
+}
+
+func TestPermissionCacheSharesRedisCopy(t *testing.T) {
+	db := &fakePermissionRepo{grants: []*repo.Permission{
+		{ID: 1, UserType: "user", Resource: "posts", Action: "create", Scope: "any"},
+	}}
+	inMemory := &fakeInMemory{values: map[string]string{}}
+
+	require.NoError(t, NewPermissionCache(db, inMemory).Load())
+	require.NoError(t, NewPermissionCache(db, inMemory).Load())
+	require.Equal(t, 1, db.loads)
+
+	// a change made by another instance is seen once the message arrives
+	other := NewPermissionCache(db, inMemory)
+	cache := NewPermissionCache(db, inMemory)
+	require.NoError(t, cache.Load())
+	_, err := other.Create(&repo.Permission{UserType: "user", Resource: "posts", Action: "delete", Scope: "own"})
+	require.NoError(t, err)
+	cache.drop()
+
+	ok, err := cache.CheckPermission(&repo.Permission{UserType: "user", Resource: "posts", Action: "delete"})
+	require.NoError(t, err)
+	require.True(t, ok)
+}
@@ -0,0 +1,289 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type accountDeletionRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountDeletion(db *sqlx.DB) repo.AccountDeletionStorageI {
+	return &accountDeletionRepo{
+		db: db,
+	}
+}
+
+// insertDeletionSteps adds the steps to the deletions of every user in user_ids
+const insertDeletionSteps = `
+	INSERT INTO account_deletion_steps (user_id, step, position)
+	SELECT u.id, s.step, s.position
+	FROM unnest($1::INTEGER[]) AS u(id)
+	CROSS JOIN unnest($2::VARCHAR[]) WITH ORDINALITY AS s(step, position)
+`
+
+func (ar *accountDeletionRepo) Create(user_id int64, steps []string) error {
+	tx, err := ar.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users SET deleted_at=COALESCE(deleted_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND purged_at IS NULL
+	`
+	result, err := tx.Exec(query, user_id)
+	if err != nil {
+		return err
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.Exec(`INSERT INTO account_deletions (user_id) VALUES ($1)`, user_id)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return repo.ErrAlreadyExists
+		}
+		return err
+	}
+
+	_, err = tx.Exec(insertDeletionSteps, pq.Array([]int64{user_id}), pq.Array(steps))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (ar *accountDeletionRepo) CreateForDeletedBefore(deleted_before time.Time, steps []string) (int64, error) {
+	tx, err := ar.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO account_deletions (user_id)
+		SELECT id FROM users WHERE deleted_at < $1 AND purged_at IS NULL
+		ON CONFLICT (user_id) DO NOTHING
+		RETURNING user_id
+	`
+	rows, err := tx.Query(query, deleted_before)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(insertDeletionSteps, pq.Array(ids), pq.Array(steps))
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), tx.Commit()
+}
+
+func (ar *accountDeletionRepo) Get(user_id int64) (*repo.AccountDeletion, error) {
+	var (
+		result      repo.AccountDeletion
+		completedAt sql.NullTime
+	)
+
+	query := `SELECT user_id, created_at, completed_at FROM account_deletions WHERE user_id = $1`
+	err := ar.db.QueryRow(query, user_id).Scan(
+		&result.UserID,
+		&result.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		result.CompletedAt = &completedAt.Time
+	}
+
+	result.Steps, err = ar.getSteps(user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (ar *accountDeletionRepo) GetPending(limit int32, lease time.Duration) ([]*repo.AccountDeletion, error) {
+	query := `
+		UPDATE account_deletions SET next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second'
+		WHERE user_id IN (
+			SELECT user_id FROM account_deletions
+			WHERE completed_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING user_id, created_at
+	`
+	rows, err := ar.db.Query(query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*repo.AccountDeletion
+	for rows.Next() {
+		var deletion repo.AccountDeletion
+		if err := rows.Scan(&deletion.UserID, &deletion.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &deletion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, deletion := range result {
+		deletion.Steps, err = ar.getSteps(deletion.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (ar *accountDeletionRepo) getSteps(user_id int64) ([]*repo.AccountDeletionStep, error) {
+	query := `
+		SELECT
+			step,
+			status,
+			attempts,
+			last_error,
+			updated_at
+		FROM account_deletion_steps WHERE user_id = $1
+		ORDER BY position
+	`
+	rows, err := ar.db.Query(query, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*repo.AccountDeletionStep
+	for rows.Next() {
+		var (
+			step      repo.AccountDeletionStep
+			lastError sql.NullString
+		)
+		err := rows.Scan(
+			&step.Name,
+			&step.Status,
+			&step.Attempts,
+			&lastError,
+			&step.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		step.LastError = lastError.String
+		result = append(result, &step)
+	}
+
+	return result, rows.Err()
+}
+
+func (ar *accountDeletionRepo) CompleteStep(user_id int64, step string) error {
+	return ar.finishStep(user_id, step, repo.DeletionStepDone, sql.NullString{})
+}
+
+func (ar *accountDeletionRepo) SkipStep(user_id int64, step, reason string) error {
+	return ar.finishStep(user_id, step, repo.DeletionStepSkipped, sql.NullString{String: reason, Valid: true})
+}
+
+// finishStep sets the final status of the step and completes the deletion
+// when it was the last pending one
+func (ar *accountDeletionRepo) finishStep(user_id int64, step, status string, last_error sql.NullString) error {
+	tx, err := ar.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE account_deletion_steps SET
+			status=$1,
+			attempts=attempts+1,
+			last_error=$2,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE user_id=$3 AND step=$4
+	`
+	result, err := tx.Exec(query, status, last_error, user_id, step)
+	if err != nil {
+		return err
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	query = `
+		UPDATE account_deletions SET completed_at=CURRENT_TIMESTAMP
+		WHERE user_id=$1 AND completed_at IS NULL AND NOT EXISTS (
+			SELECT 1 FROM account_deletion_steps WHERE user_id=$1 AND status=$2
+		)
+	`
+	_, err = tx.Exec(query, user_id, repo.DeletionStepPending)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (ar *accountDeletionRepo) FailStep(user_id int64, step, last_error string, retry_at time.Time) error {
+	tx, err := ar.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE account_deletion_steps SET
+			attempts=attempts+1,
+			last_error=$1,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE user_id=$2 AND step=$3
+	`
+	result, err := tx.Exec(query, last_error, user_id, step)
+	if err != nil {
+		return err
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.Exec(`UPDATE account_deletions SET next_attempt_at=$1 WHERE user_id=$2`, retry_at, user_id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
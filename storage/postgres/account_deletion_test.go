@@ -0,0 +1,117 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDeletion(t *testing.T) {
+	user := createUser(t)
+
+	steps := []string{"delete_posts", "purge_user"}
+	err := dbManager.AccountDeletion().Create(user.ID, steps)
+	require.NoError(t, err)
+
+	_, err = dbManager.User().Get(user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = dbManager.AccountDeletion().Create(user.ID, steps)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+
+	err = dbManager.User().Restore(user.ID, time.Now().Add(-time.Hour))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = dbManager.AccountDeletion().FailStep(user.ID, "delete_posts", "post service is unavailable", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	pending, err := dbManager.AccountDeletion().GetPending(100, time.Minute)
+	require.NoError(t, err)
+	for _, deletion := range pending {
+		require.NotEqual(t, user.ID, deletion.UserID)
+	}
+
+	deletion, err := dbManager.AccountDeletion().Get(user.ID)
+	require.NoError(t, err)
+	require.Len(t, deletion.Steps, 2)
+	require.Equal(t, "delete_posts", deletion.Steps[0].Name)
+	require.Equal(t, repo.DeletionStepPending, deletion.Steps[0].Status)
+	require.Equal(t, int32(1), deletion.Steps[0].Attempts)
+	require.Equal(t, "post service is unavailable", deletion.Steps[0].LastError)
+
+	for _, step := range steps {
+		err = dbManager.AccountDeletion().CompleteStep(user.ID, step)
+		require.NoError(t, err)
+	}
+
+	deletion, err = dbManager.AccountDeletion().Get(user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, deletion.CompletedAt)
+	require.Equal(t, repo.DeletionStepDone, deletion.Steps[1].Status)
+}
+
+func TestSkipAccountDeletionStep(t *testing.T) {
+	user := createUser(t)
+
+	err := dbManager.AccountDeletion().Create(user.ID, []string{"delete_likes", "purge_user"})
+	require.NoError(t, err)
+
+	err = dbManager.AccountDeletion().SkipStep(user.ID, "delete_likes", "not supported")
+	require.NoError(t, err)
+
+	deletion, err := dbManager.AccountDeletion().Get(user.ID)
+	require.NoError(t, err)
+	require.Nil(t, deletion.CompletedAt)
+	require.Equal(t, repo.DeletionStepSkipped, deletion.Steps[0].Status)
+	require.Equal(t, "not supported", deletion.Steps[0].LastError)
+
+	err = dbManager.AccountDeletion().CompleteStep(user.ID, "purge_user")
+	require.NoError(t, err)
+
+	deletion, err = dbManager.AccountDeletion().Get(user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, deletion.CompletedAt)
+}
+
+func TestGetPendingAccountDeletions(t *testing.T) {
+	user := createUser(t)
+
+	err := dbManager.AccountDeletion().Create(user.ID, []string{"purge_user"})
+	require.NoError(t, err)
+
+	pending, err := dbManager.AccountDeletion().GetPending(100, time.Minute)
+	require.NoError(t, err)
+	require.True(t, containsDeletion(pending, user.ID))
+
+	// claimed until the lease expires
+	pending, err = dbManager.AccountDeletion().GetPending(100, time.Minute)
+	require.NoError(t, err)
+	require.False(t, containsDeletion(pending, user.ID))
+}
+
+func containsDeletion(deletions []*repo.AccountDeletion, userID int64) bool {
+	for _, deletion := range deletions {
+		if deletion.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPurgeUser(t *testing.T) {
+	user := createUser(t)
+	deleteUser(t, user.ID)
+
+	u, err := dbManager.User().Purge(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user.Email, u.Email)
+
+	_, err = dbManager.User().Purge(user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = dbManager.User().GetDeleted(user.Email, "")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type blockRepo struct {
+	db *sqlx.DB
+}
+
+func NewBlock(db *sqlx.DB) repo.BlockStorageI {
+	return &blockRepo{
+		db: db,
+	}
+}
+
+func (br *blockRepo) Block(blocker_id, blocked_id int64) error {
+	tx, err := br.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2)`
+	_, err = tx.Exec(query, blocker_id, blocked_id)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return repo.ErrAlreadyExists
+		}
+		if isPqError(err, foreignKeyViolation) {
+			return sql.ErrNoRows
+		}
+		return err
+	}
+
+	query = `
+		DELETE FROM follows
+		WHERE (follower_id = $1 AND following_id = $2) OR (follower_id = $2 AND following_id = $1)
+	`
+	_, err = tx.Exec(query, blocker_id, blocked_id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (br *blockRepo) Unblock(blocker_id, blocked_id int64) error {
+	query := `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`
+	result, err := br.db.Exec(query, blocker_id, blocked_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (br *blockRepo) ListBlocked(params *repo.GetBlocksParams) (*repo.GetAllUsersResult, error) {
+	return listRelatedUsers(br.db, "blocks", "blocked_id", "blocker_id", params.UserID, params.Limit, params.Page)
+}
+
+func (br *blockRepo) IsBlocked(a, b int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	var exists bool
+	err := br.db.QueryRow(query, a, b).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (br *blockRepo) Mute(muter_id, muted_id int64) error {
+	query := `INSERT INTO mutes (muter_id, muted_id) VALUES ($1, $2)`
+	_, err := br.db.Exec(query, muter_id, muted_id)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return repo.ErrAlreadyExists
+		}
+		if isPqError(err, foreignKeyViolation) {
+			return sql.ErrNoRows
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (br *blockRepo) Unmute(muter_id, muted_id int64) error {
+	query := `DELETE FROM mutes WHERE muter_id = $1 AND muted_id = $2`
+	result, err := br.db.Exec(query, muter_id, muted_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (br *blockRepo) ListMuted(params *repo.GetBlocksParams) (*repo.GetAllUsersResult, error) {
+	return listRelatedUsers(br.db, "mutes", "muted_id", "muter_id", params.UserID, params.Limit, params.Page)
+}
@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRemovesFollows(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+	other := createUser(t)
+	defer deleteUser(t, other.ID)
+
+	require.NoError(t, dbManager.Follow().Follow(user.ID, other.ID))
+	require.NoError(t, dbManager.Follow().Follow(other.ID, user.ID))
+
+	err := dbManager.Block().Block(user.ID, other.ID)
+	require.NoError(t, err)
+
+	counts, err := dbManager.Follow().GetCounts(user.ID)
+	require.NoError(t, err)
+	require.Zero(t, counts[user.ID].Followers)
+	require.Zero(t, counts[user.ID].Following)
+
+	err = dbManager.Follow().Follow(other.ID, user.ID)
+	require.ErrorIs(t, err, repo.ErrBlocked)
+
+	isBlocked, err := dbManager.Block().IsBlocked(other.ID, user.ID)
+	require.NoError(t, err)
+	require.True(t, isBlocked)
+
+	require.NoError(t, dbManager.Block().Unblock(user.ID, other.ID))
+
+	isBlocked, err = dbManager.Block().IsBlocked(user.ID, other.ID)
+	require.NoError(t, err)
+	require.False(t, isBlocked)
+}
+
+func TestMute(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+	other := createUser(t)
+	defer deleteUser(t, other.ID)
+
+	require.NoError(t, dbManager.Block().Mute(user.ID, other.ID))
+	require.ErrorIs(t, dbManager.Block().Mute(user.ID, other.ID), repo.ErrAlreadyExists)
+
+	muted, err := dbManager.Block().ListMuted(&repo.GetBlocksParams{
+		UserID: user.ID,
+		Limit:  10,
+		Page:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), muted.Count)
+
+	require.NoError(t, dbManager.Block().Unmute(user.ID, other.ID))
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type followRepo struct {
+	db *sqlx.DB
+}
+
+func NewFollow(db *sqlx.DB) repo.FollowStorageI {
+	return &followRepo{
+		db: db,
+	}
+}
+
+func (fr *followRepo) Follow(follower_id, following_id int64) error {
+	query := `
+		INSERT INTO follows (follower_id, following_id)
+		SELECT $1, $2 WHERE NOT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+	result, err := fr.db.Exec(query, follower_id, following_id)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return repo.ErrAlreadyExists
+		}
+		if isPqError(err, foreignKeyViolation) {
+			return sql.ErrNoRows
+		}
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return repo.ErrBlocked
+	}
+
+	return nil
+}
+
+func (fr *followRepo) Unfollow(follower_id, following_id int64) error {
+	query := `DELETE FROM follows WHERE follower_id = $1 AND following_id = $2`
+	result, err := fr.db.Exec(query, follower_id, following_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (fr *followRepo) ListFollowers(params *repo.GetFollowsParams) (*repo.GetAllUsersResult, error) {
+	return listRelatedUsers(fr.db, "follows", "follower_id", "following_id", params.UserID, params.Limit, params.Page)
+}
+
+func (fr *followRepo) ListFollowing(params *repo.GetFollowsParams) (*repo.GetAllUsersResult, error) {
+	return listRelatedUsers(fr.db, "follows", "following_id", "follower_id", params.UserID, params.Limit, params.Page)
+}
+
+// listRelatedUsers returns the users in column of the rows of table where
+// by is user_id, the latest rows first
+func listRelatedUsers(db *sqlx.DB, table, column, by string, user_id int64, limit, page int32) (*repo.GetAllUsersResult, error) {
+	result := repo.GetAllUsersResult{
+		Users: make([]*repo.User, 0),
+	}
+
+	offset := (page - 1) * limit
+
+	query := `
+		SELECT ` + userColumns + ` FROM users
+		JOIN (
+			SELECT ` + column + ` AS user_id, created_at AS related_at
+			FROM ` + table + ` WHERE ` + by + ` = $1
+		) r ON r.user_id = users.id
+		WHERE users.deleted_at IS NULL
+		ORDER BY r.related_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := db.Query(query, user_id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Users = append(result.Users, user)
+	}
+
+	queryCount := `
+		SELECT count(1) FROM ` + table + `
+		JOIN users ON users.id = ` + column + ` AND users.deleted_at IS NULL
+		WHERE ` + by + ` = $1
+	`
+	if err = db.QueryRow(queryCount, user_id).Scan(&result.Count); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (fr *followRepo) IsFollowing(follower_id, following_id int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND following_id = $2)`
+
+	var exists bool
+	err := fr.db.QueryRow(query, follower_id, following_id).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (fr *followRepo) GetCounts(user_ids ...int64) (map[int64]*repo.FollowCounts, error) {
+	result := make(map[int64]*repo.FollowCounts, len(user_ids))
+	for _, id := range user_ids {
+		result[id] = &repo.FollowCounts{}
+	}
+	if len(user_ids) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT
+			id,
+			(SELECT count(1) FROM follows JOIN users u ON u.id = follower_id AND u.deleted_at IS NULL WHERE following_id = id),
+			(SELECT count(1) FROM follows JOIN users u ON u.id = following_id AND u.deleted_at IS NULL WHERE follower_id = id)
+		FROM unnest($1::INTEGER[]) AS id
+	`
+	rows, err := fr.db.Query(query, pq.Array(user_ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id     int64
+			counts repo.FollowCounts
+		)
+		err := rows.Scan(&id, &counts.Followers, &counts.Following)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = &counts
+	}
+
+	return result, rows.Err()
+}
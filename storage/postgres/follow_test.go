@@ -0,0 +1,46 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollow(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+	follower := createUser(t)
+	defer deleteUser(t, follower.ID)
+
+	err := dbManager.Follow().Follow(follower.ID, user.ID)
+	require.NoError(t, err)
+
+	err = dbManager.Follow().Follow(follower.ID, user.ID)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+
+	isFollowing, err := dbManager.Follow().IsFollowing(follower.ID, user.ID)
+	require.NoError(t, err)
+	require.True(t, isFollowing)
+
+	followers, err := dbManager.Follow().ListFollowers(&repo.GetFollowsParams{
+		UserID: user.ID,
+		Limit:  10,
+		Page:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), followers.Count)
+	require.Equal(t, follower.ID, followers.Users[0].ID)
+
+	counts, err := dbManager.Follow().GetCounts(user.ID, follower.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), counts[user.ID].Followers)
+	require.Equal(t, int64(1), counts[follower.ID].Following)
+
+	err = dbManager.Follow().Unfollow(follower.ID, user.ID)
+	require.NoError(t, err)
+
+	isFollowing, err = dbManager.Follow().IsFollowing(follower.ID, user.ID)
+	require.NoError(t, err)
+	require.False(t, isFollowing)
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type identityRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdentity(db *sqlx.DB) repo.IdentityStorageI {
+	return &identityRepo{
+		db: db,
+	}
+}
+
+func (ir *identityRepo) Create(identity *repo.Identity) (*repo.Identity, error) {
+	query := `
+		INSERT INTO user_identities (
+			user_id,
+			provider,
+			subject,
+			email
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := ir.db.QueryRow(
+		query,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		utils.NullString(identity.Email),
+	).Scan(
+		&identity.ID,
+		&identity.CreatedAt,
+	)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return nil, repo.ErrAlreadyExists
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (ir *identityRepo) Get(provider, subject string) (*repo.Identity, error) {
+	var (
+		result repo.Identity
+		email  sql.NullString
+	)
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			provider,
+			subject,
+			email,
+			created_at
+		FROM user_identities WHERE provider = $1 AND subject = $2
+	`
+	err := ir.db.QueryRow(
+		query,
+		provider,
+		subject,
+	).Scan(
+		&result.ID,
+		&result.UserID,
+		&result.Provider,
+		&result.Subject,
+		&email,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Email = email.String
+
+	return &result, nil
+}
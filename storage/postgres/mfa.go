@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type mfaRepo struct {
+	db *sqlx.DB
+}
+
+func NewMFA(db *sqlx.DB) repo.MFAStorageI {
+	return &mfaRepo{
+		db: db,
+	}
+}
+
+// SaveSecret stores a new, not yet enabled secret. An already enabled
+// secret is never overwritten, 2FA has to be disabled first.
+func (mr *mfaRepo) SaveSecret(user_id int64, secret string) error {
+	query := `
+		INSERT INTO user_mfa (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			created_at = CURRENT_TIMESTAMP
+		WHERE user_mfa.enabled_at IS NULL
+	`
+	result, err := mr.db.Exec(query, user_id, secret)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (mr *mfaRepo) Get(user_id int64) (*repo.MFA, error) {
+	var (
+		result    repo.MFA
+		enabledAt sql.NullTime
+	)
+
+	query := `
+		SELECT
+			user_id,
+			secret,
+			enabled_at,
+			created_at
+		FROM user_mfa WHERE user_id = $1
+	`
+	err := mr.db.QueryRow(
+		query,
+		user_id,
+	).Scan(
+		&result.UserID,
+		&result.Secret,
+		&enabledAt,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if enabledAt.Valid {
+		result.EnabledAt = &enabledAt.Time
+	}
+
+	return &result, nil
+}
+
+func (mr *mfaRepo) Enable(user_id int64) error {
+	query := `
+		UPDATE user_mfa SET enabled_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`
+	result, err := mr.db.Exec(query, user_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (mr *mfaRepo) Delete(user_id int64) error {
+	tx, err := mr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, user_id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM user_mfa WHERE user_id = $1`, user_id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mr *mfaRepo) ReplaceRecoveryCodes(user_id int64, code_hashes []string) error {
+	tx, err := mr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, user_id)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range code_hashes {
+		_, err = tx.Exec(`INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, user_id, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (mr *mfaRepo) GetRecoveryCodes(user_id int64) ([]*repo.RecoveryCode, error) {
+	result := make([]*repo.RecoveryCode, 0)
+
+	query := `
+		SELECT id, user_id, code_hash FROM mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	rows, err := mr.db.Query(query, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code repo.RecoveryCode
+		if err := rows.Scan(&code.ID, &code.UserID, &code.CodeHash); err != nil {
+			return nil, err
+		}
+		result = append(result, &code)
+	}
+
+	return result, nil
+}
+
+// UseRecoveryCode marks the code as used, it returns sql.ErrNoRows if the
+// code has been used already.
+func (mr *mfaRepo) UseRecoveryCode(id int64) error {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL
+	`
+	result, err := mr.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type notificationPreferenceRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPreference(db *sqlx.DB) repo.NotificationPreferenceStorageI {
+	return &notificationPreferenceRepo{
+		db: db,
+	}
+}
+
+func (nr *notificationPreferenceRepo) GetAll(user_id int64) ([]*repo.NotificationPreference, error) {
+	query := `
+		SELECT
+			user_id,
+			category,
+			channel,
+			enabled,
+			updated_at
+		FROM notification_preferences WHERE user_id = $1
+		ORDER BY category, channel
+	`
+	rows, err := nr.db.Query(query, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*repo.NotificationPreference
+	for rows.Next() {
+		var preference repo.NotificationPreference
+		err := rows.Scan(
+			&preference.UserID,
+			&preference.Category,
+			&preference.Channel,
+			&preference.Enabled,
+			&preference.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &preference)
+	}
+
+	return result, rows.Err()
+}
+
+func (nr *notificationPreferenceRepo) Set(preferences []*repo.NotificationPreference) error {
+	tx, err := nr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO notification_preferences (
+			user_id,
+			category,
+			channel,
+			enabled
+		) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, category, channel) DO UPDATE SET
+			enabled=EXCLUDED.enabled,
+			updated_at=CURRENT_TIMESTAMP
+	`
+	for _, preference := range preferences {
+		_, err := tx.Exec(
+			query,
+			preference.UserID,
+			preference.Category,
+			preference.Channel,
+			preference.Enabled,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,31 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetNotificationPreferences(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	err := dbManager.NotificationPreference().Set([]*repo.NotificationPreference{
+		{UserID: user.ID, Category: "social", Channel: "email", Enabled: false},
+		{UserID: user.ID, Category: "marketing", Channel: "email", Enabled: true},
+	})
+	require.NoError(t, err)
+
+	err = dbManager.NotificationPreference().Set([]*repo.NotificationPreference{
+		{UserID: user.ID, Category: "social", Channel: "email", Enabled: true},
+	})
+	require.NoError(t, err)
+
+	preferences, err := dbManager.NotificationPreference().GetAll(user.ID)
+	require.NoError(t, err)
+	require.Len(t, preferences, 2)
+	for _, preference := range preferences {
+		require.True(t, preference.Enabled)
+	}
+}
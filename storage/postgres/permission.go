@@ -24,13 +24,85 @@ func (pd *permissionRepo) CheckPermission(p *repo.Permission) (bool, error) {
 		WHERE user_type = $1 AND resource = $2 AND action = $3
 	`
 
-	var id int64 
+	var id int64
 	err := pd.db.QueryRow(query, p.UserType, p.Resource, p.Action).Scan(&id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, nil 
+			return false, nil
 		}
-		return false, nil 
+		return false, nil
 	}
-	return true, nil 
-}
\ No newline at end of file
+	return true, nil
+}
+
+// Create returns repo.ErrRoleNotFound if there is no such role and
+// repo.ErrAlreadyExists if the role already has the grant
+func (pd *permissionRepo) Create(p *repo.Permission) (*repo.Permission, error) {
+	query := `
+		INSERT INTO permissions (
+			user_type,
+			resource,
+			action,
+			scope
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	err := pd.db.QueryRow(query, p.UserType, p.Resource, p.Action, p.Scope).Scan(&p.ID)
+	if err != nil {
+		if isPqError(err, foreignKeyViolation) {
+			return nil, repo.ErrRoleNotFound
+		}
+		if isPqError(err, uniqueViolation) {
+			return nil, repo.ErrAlreadyExists
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (pd *permissionRepo) Delete(permission_id int64) error {
+	query := `DELETE FROM permissions WHERE id = $1`
+	result, err := pd.db.Exec(query, permission_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (pd *permissionRepo) GetAll(user_type string) ([]*repo.Permission, error) {
+	result := make([]*repo.Permission, 0)
+
+	query := `
+		SELECT id, user_type, resource, action, scope FROM permissions
+		WHERE $1 = '' OR user_type = $1
+		ORDER BY user_type, resource, action
+	`
+	rows, err := pd.db.Query(query, user_type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p repo.Permission
+		err := rows.Scan(
+			&p.ID,
+			&p.UserType,
+			&p.Resource,
+			&p.Action,
+			&p.Scope,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &p)
+	}
+
+	return result, nil
+}
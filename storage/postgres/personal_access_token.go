@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type personalAccessTokenRepo struct {
+	db *sqlx.DB
+}
+
+func NewPersonalAccessToken(db *sqlx.DB) repo.PersonalAccessTokenStorageI {
+	return &personalAccessTokenRepo{
+		db: db,
+	}
+}
+
+func (pr *personalAccessTokenRepo) Create(token *repo.PersonalAccessToken) (*repo.PersonalAccessToken, error) {
+	query := `
+		INSERT INTO personal_access_tokens (
+			user_id,
+			name,
+			token_hash,
+			prefix,
+			scopes,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := pr.db.QueryRow(
+		query,
+		token.UserID,
+		token.Name,
+		token.TokenHash,
+		token.Prefix,
+		pq.Array(encodeScopes(token.Scopes)),
+		token.ExpiresAt,
+	).Scan(
+		&token.ID,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (pr *personalAccessTokenRepo) GetByHash(token_hash string) (*repo.PersonalAccessToken, error) {
+	query := `
+		SELECT ` + personalAccessTokenColumns + `
+		FROM personal_access_tokens WHERE token_hash = $1
+	`
+
+	return scanPersonalAccessToken(pr.db.QueryRow(query, token_hash))
+}
+
+func (pr *personalAccessTokenRepo) GetAllByUser(user_id int64) ([]*repo.PersonalAccessToken, error) {
+	result := make([]*repo.PersonalAccessToken, 0)
+
+	query := `
+		SELECT ` + personalAccessTokenColumns + `
+		FROM personal_access_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	rows, err := pr.db.Query(query, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, token)
+	}
+
+	return result, nil
+}
+
+func (pr *personalAccessTokenRepo) UpdateLastUsed(id int64) error {
+	query := `
+		UPDATE personal_access_tokens SET last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')
+	`
+	_, err := pr.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pr *personalAccessTokenRepo) Revoke(user_id, id int64) error {
+	query := `
+		UPDATE personal_access_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+	result, err := pr.db.Exec(query, id, user_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const personalAccessTokenColumns = `
+			id,
+			user_id,
+			name,
+			token_hash,
+			prefix,
+			scopes,
+			expires_at,
+			last_used_at,
+			created_at,
+			revoked_at
+`
+
+func scanPersonalAccessToken(row rowScanner) (*repo.PersonalAccessToken, error) {
+	var (
+		result                           repo.PersonalAccessToken
+		scopes                           []string
+		expiresAt, lastUsedAt, revokedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&result.ID,
+		&result.UserID,
+		&result.Name,
+		&result.TokenHash,
+		&result.Prefix,
+		pq.Array(&scopes),
+		&expiresAt,
+		&lastUsedAt,
+		&result.CreatedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Scopes = decodeScopes(scopes)
+	if expiresAt.Valid {
+		result.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		result.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		result.RevokedAt = &revokedAt.Time
+	}
+
+	return &result, nil
+}
+
+// scopes are kept as "resource:action" strings
+func encodeScopes(scopes []*repo.TokenScope) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, scope.Resource+":"+scope.Action)
+	}
+	return result
+}
+
+func decodeScopes(scopes []string) []*repo.TokenScope {
+	result := make([]*repo.TokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		resource, action, _ := strings.Cut(scope, ":")
+		result = append(result, &repo.TokenScope{
+			Resource: resource,
+			Action:   action,
+		})
+	}
+	return result
+}
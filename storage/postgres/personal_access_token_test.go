@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func createPersonalAccessToken(t *testing.T, user_id int64) (*repo.PersonalAccessToken, string) {
+	token, err := utils.GenerateOpaqueToken(32)
+	require.NoError(t, err)
+
+	pat, err := dbManager.PersonalAccessToken().Create(&repo.PersonalAccessToken{
+		UserID:    user_id,
+		Name:      "ci",
+		TokenHash: utils.HashOpaqueToken(token),
+		Prefix:    token[:8],
+		Scopes: []*repo.TokenScope{
+			{Resource: "posts", Action: "create"},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, pat)
+	return pat, token
+}
+
+func TestGetPersonalAccessTokenByHash(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	pat, token := createPersonalAccessToken(t, user.ID)
+
+	result, err := dbManager.PersonalAccessToken().GetByHash(utils.HashOpaqueToken(token))
+	require.NoError(t, err)
+	require.Equal(t, pat.ID, result.ID)
+	require.Len(t, result.Scopes, 1)
+	require.Equal(t, "posts", result.Scopes[0].Resource)
+	require.Equal(t, "create", result.Scopes[0].Action)
+	require.Nil(t, result.ExpiresAt)
+}
+
+func TestRevokePersonalAccessToken(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	pat, _ := createPersonalAccessToken(t, user.ID)
+
+	err := dbManager.PersonalAccessToken().Revoke(user.ID, pat.ID)
+	require.NoError(t, err)
+
+	tokens, err := dbManager.PersonalAccessToken().GetAllByUser(user.ID)
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+}
+
+func TestUpdatePersonalAccessTokenLastUsed(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	pat, token := createPersonalAccessToken(t, user.ID)
+
+	require.NoError(t, dbManager.PersonalAccessToken().UpdateLastUsed(pat.ID))
+	result, err := dbManager.PersonalAccessToken().GetByHash(utils.HashOpaqueToken(token))
+	require.NoError(t, err)
+	require.NotNil(t, result.LastUsedAt)
+
+	// a second use within the minute does not move it
+	require.NoError(t, dbManager.PersonalAccessToken().UpdateLastUsed(pat.ID))
+	again, err := dbManager.PersonalAccessToken().GetByHash(utils.HashOpaqueToken(token))
+	require.NoError(t, err)
+	require.Equal(t, result.LastUsedAt.UnixNano(), again.LastUsedAt.UnixNano())
+}
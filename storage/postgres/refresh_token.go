@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type refreshTokenRepo struct {
+	db *sqlx.DB
+}
+
+func NewRefreshToken(db *sqlx.DB) repo.RefreshTokenStorageI {
+	return &refreshTokenRepo{
+		db: db,
+	}
+}
+
+func (rr *refreshTokenRepo) Create(token *repo.RefreshToken) (*repo.RefreshToken, error) {
+	query := `
+		INSERT INTO refresh_tokens (
+			user_id,
+			family_id,
+			token_hash,
+			expires_at
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := rr.db.QueryRow(
+		query,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+	).Scan(
+		&token.ID,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (rr *refreshTokenRepo) GetByHash(token_hash string) (*repo.RefreshToken, error) {
+	var (
+		result               repo.RefreshToken
+		rotatedAt, revokedAt sql.NullTime
+	)
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			family_id,
+			token_hash,
+			expires_at,
+			rotated_at,
+			revoked_at,
+			created_at
+		FROM refresh_tokens WHERE token_hash = $1
+	`
+	err := rr.db.QueryRow(
+		query,
+		token_hash,
+	).Scan(
+		&result.ID,
+		&result.UserID,
+		&result.FamilyID,
+		&result.TokenHash,
+		&result.ExpiresAt,
+		&rotatedAt,
+		&revokedAt,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rotatedAt.Valid {
+		result.RotatedAt = &rotatedAt.Time
+	}
+	if revokedAt.Valid {
+		result.RevokedAt = &revokedAt.Time
+	}
+
+	return &result, nil
+}
+
+// Rotate marks the token as used. It returns sql.ErrNoRows when the token
+// has already been rotated or revoked, so concurrent refreshes of the same
+// token can not both succeed.
+func (rr *refreshTokenRepo) Rotate(id int64) error {
+	query := `
+		UPDATE refresh_tokens SET rotated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+	`
+	result, err := rr.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (rr *refreshTokenRepo) RevokeFamily(family_id string) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+	_, err := rr.db.Exec(query, family_id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (rr *refreshTokenRepo) RevokeAllByUser(user_id int64) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	_, err := rr.db.Exec(query, user_id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
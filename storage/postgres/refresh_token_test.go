@@ -0,0 +1,61 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRefreshToken(t *testing.T, user_id int64, family_id string) *repo.RefreshToken {
+	token, err := utils.GenerateOpaqueToken(32)
+	require.NoError(t, err)
+	rt, err := dbManager.RefreshToken().Create(&repo.RefreshToken{
+		UserID:    user_id,
+		FamilyID:  family_id,
+		TokenHash: utils.HashOpaqueToken(token),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rt)
+	return rt
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	rt := createRefreshToken(t, user.ID, uuid.NewString())
+
+	err := dbManager.RefreshToken().Rotate(rt.ID)
+	require.NoError(t, err)
+
+	err = dbManager.RefreshToken().Rotate(rt.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	got, err := dbManager.RefreshToken().GetByHash(rt.TokenHash)
+	require.NoError(t, err)
+	require.NotNil(t, got.RotatedAt)
+}
+
+func TestRevokeRefreshTokenFamily(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	familyID := uuid.NewString()
+	first := createRefreshToken(t, user.ID, familyID)
+	second := createRefreshToken(t, user.ID, familyID)
+
+	err := dbManager.RefreshToken().RevokeFamily(familyID)
+	require.NoError(t, err)
+
+	for _, rt := range []*repo.RefreshToken{first, second} {
+		got, err := dbManager.RefreshToken().GetByHash(rt.TokenHash)
+		require.NoError(t, err)
+		require.NotNil(t, got.RevokedAt)
+	}
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+func isPqError(err error, code string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && string(pqErr.Code) == code
+}
+
+type roleRepo struct {
+	db *sqlx.DB
+}
+
+func NewRole(db *sqlx.DB) repo.RoleStorageI {
+	return &roleRepo{
+		db: db,
+	}
+}
+
+func (rr *roleRepo) Create(role *repo.Role) (*repo.Role, error) {
+	query := `
+		INSERT INTO roles (
+			name,
+			description
+		) VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+	err := rr.db.QueryRow(
+		query,
+		role.Name,
+		utils.NullString(role.Description),
+	).Scan(
+		&role.ID,
+		&role.CreatedAt,
+	)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return nil, repo.ErrAlreadyExists
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (rr *roleRepo) Get(role_id int64) (*repo.Role, error) {
+	query := `SELECT id, name, description, created_at FROM roles WHERE id = $1`
+
+	return scanRole(rr.db.QueryRow(query, role_id))
+}
+
+func (rr *roleRepo) GetAll() ([]*repo.Role, error) {
+	result := make([]*repo.Role, 0)
+
+	query := `SELECT id, name, description, created_at FROM roles ORDER BY id`
+	rows, err := rr.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, role)
+	}
+
+	return result, nil
+}
+
+// Update renames the role in users and permissions as well
+func (rr *roleRepo) Update(role *repo.Role) (*repo.Role, error) {
+	query := `
+		UPDATE roles SET
+			name=$1,
+			description=$2
+		WHERE id=$3
+		RETURNING created_at
+	`
+	err := rr.db.QueryRow(
+		query,
+		role.Name,
+		utils.NullString(role.Description),
+		role.ID,
+	).Scan(&role.CreatedAt)
+	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return nil, repo.ErrAlreadyExists
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Delete removes the role with its grants, it returns repo.ErrRoleInUse
+// while users still have the role
+func (rr *roleRepo) Delete(role_id int64) error {
+	query := `DELETE FROM roles WHERE id = $1`
+	result, err := rr.db.Exec(query, role_id)
+	if err != nil {
+		if isPqError(err, foreignKeyViolation) {
+			return repo.ErrRoleInUse
+		}
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanRole(row rowScanner) (*repo.Role, error) {
+	var (
+		result      repo.Role
+		description sql.NullString
+	)
+
+	err := row.Scan(
+		&result.ID,
+		&result.Name,
+		&description,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Description = description.String
+
+	return &result, nil
+}
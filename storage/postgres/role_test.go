@@ -0,0 +1,68 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/bxcodec/faker/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func createRole(t *testing.T) *repo.Role {
+	role, err := dbManager.Role().Create(&repo.Role{
+		Name:        faker.Username(),
+		Description: faker.Sentence(),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, role)
+	return role
+}
+
+func deleteRole(t *testing.T, id int64) {
+	err := dbManager.Role().Delete(id)
+	require.NoError(t, err)
+}
+
+func TestRoleGrants(t *testing.T) {
+	role := createRole(t)
+	defer deleteRole(t, role.ID)
+
+	grant, err := dbManager.Permission().Create(&repo.Permission{
+		UserType: role.Name,
+		Resource: "posts",
+		Action:   "delete",
+		Scope:    "own",
+	})
+	require.NoError(t, err)
+
+	_, err = dbManager.Permission().Create(grant)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+
+	hasPermission, err := dbManager.Permission().CheckPermission(grant)
+	require.NoError(t, err)
+	require.True(t, hasPermission)
+
+	grants, err := dbManager.Permission().GetAll(role.Name)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+}
+
+func TestAssignRole(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	role := createRole(t)
+
+	err := dbManager.User().UpdateType(user.ID, role.Name)
+	require.NoError(t, err)
+
+	err = dbManager.Role().Delete(role.ID)
+	require.ErrorIs(t, err, repo.ErrRoleInUse)
+
+	err = dbManager.User().UpdateType(user.ID, "no-such-role")
+	require.ErrorIs(t, err, repo.ErrRoleNotFound)
+
+	err = dbManager.User().UpdateType(user.ID, repo.UserTypeUser)
+	require.NoError(t, err)
+	deleteRole(t, role.ID)
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/pkg/utils"
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type sessionRepo struct {
+	db *sqlx.DB
+}
+
+func NewSession(db *sqlx.DB) repo.SessionStorageI {
+	return &sessionRepo{
+		db: db,
+	}
+}
+
+func (sr *sessionRepo) Create(session *repo.Session) (*repo.Session, error) {
+	query := `
+		INSERT INTO sessions (
+			id,
+			user_id,
+			token_id,
+			user_agent,
+			ip_address
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, last_seen_at
+	`
+	err := sr.db.QueryRow(
+		query,
+		session.ID,
+		session.UserID,
+		session.TokenID,
+		utils.NullString(session.UserAgent),
+		utils.NullString(session.IpAddress),
+	).Scan(
+		&session.CreatedAt,
+		&session.LastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (sr *sessionRepo) Get(session_id string) (*repo.Session, error) {
+	var (
+		result               repo.Session
+		userAgent, ipAddress sql.NullString
+		revokedAt            sql.NullTime
+	)
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			token_id,
+			user_agent,
+			ip_address,
+			created_at,
+			last_seen_at,
+			revoked_at
+		FROM sessions WHERE id = $1
+	`
+	err := sr.db.QueryRow(
+		query,
+		session_id,
+	).Scan(
+		&result.ID,
+		&result.UserID,
+		&result.TokenID,
+		&userAgent,
+		&ipAddress,
+		&result.CreatedAt,
+		&result.LastSeenAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.UserAgent = userAgent.String
+	result.IpAddress = ipAddress.String
+	if revokedAt.Valid {
+		result.RevokedAt = &revokedAt.Time
+	}
+
+	return &result, nil
+}
+
+func (sr *sessionRepo) UpdateToken(session_id, token_id string) error {
+	query := `
+		UPDATE sessions SET
+			token_id = $1,
+			last_seen_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+	result, err := sr.db.Exec(query, token_id, session_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (sr *sessionRepo) GetAllByUser(user_id int64) ([]*repo.Session, error) {
+	result := make([]*repo.Session, 0)
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			token_id,
+			user_agent,
+			ip_address,
+			created_at,
+			last_seen_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY last_seen_at DESC
+	`
+	rows, err := sr.db.Query(query, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			session              repo.Session
+			userAgent, ipAddress sql.NullString
+		)
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.TokenID,
+			&userAgent,
+			&ipAddress,
+			&session.CreatedAt,
+			&session.LastSeenAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		session.UserAgent = userAgent.String
+		session.IpAddress = ipAddress.String
+		result = append(result, &session)
+	}
+
+	return result, nil
+}
+
+func (sr *sessionRepo) Revoke(session_id string) error {
+	query := `
+		UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	result, err := sr.db.Exec(query, session_id)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (sr *sessionRepo) RevokeAllByUser(user_id int64) error {
+	query := `
+		UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	_, err := sr.db.Exec(query, user_id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createSession(t *testing.T, user_id int64) *repo.Session {
+	session, err := dbManager.Session().Create(&repo.Session{
+		ID:        uuid.NewString(),
+		UserID:    user_id,
+		TokenID:   uuid.NewString(),
+		UserAgent: "Mozilla/5.0",
+		IpAddress: "127.0.0.1",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+	return session
+}
+
+func TestListSessions(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	createSession(t, user.ID)
+	session := createSession(t, user.ID)
+
+	err := dbManager.Session().UpdateToken(session.ID, uuid.NewString())
+	require.NoError(t, err)
+
+	sessions, err := dbManager.Session().GetAllByUser(user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+}
+
+func TestRevokeSession(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	session := createSession(t, user.ID)
+
+	err := dbManager.Session().Revoke(session.ID)
+	require.NoError(t, err)
+
+	s, err := dbManager.Session().Get(session.ID)
+	require.NoError(t, err)
+	require.NotNil(t, s.RevokedAt)
+
+	sessions, err := dbManager.Session().GetAllByUser(user.ID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/jmoiron/sqlx"
+)
+
+type settingsRepo struct {
+	db *sqlx.DB
+}
+
+func NewSettings(db *sqlx.DB) repo.SettingsStorageI {
+	return &settingsRepo{
+		db: db,
+	}
+}
+
+const settingsColumns = `
+			user_id,
+			schema_version,
+			language,
+			timezone,
+			theme,
+			default_post_visibility,
+			email_frequency,
+			updated_at
+`
+
+func (sr *settingsRepo) Get(user_id int64) (*repo.UserSettings, error) {
+	query := `SELECT ` + settingsColumns + ` FROM user_settings WHERE user_id = $1`
+
+	return scanSettings(sr.db.QueryRow(query, user_id))
+}
+
+// Update creates the settings of the user on the first change. A nil field
+// keeps the saved value and NULLIF turns an empty one into NULL.
+func (sr *settingsRepo) Update(params *repo.UpdateSettingsParams) (*repo.UserSettings, error) {
+	query := `
+		INSERT INTO user_settings (
+			user_id,
+			schema_version,
+			language,
+			timezone,
+			theme,
+			default_post_visibility,
+			email_frequency
+		) VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''))
+		ON CONFLICT (user_id) DO UPDATE SET
+			schema_version=EXCLUDED.schema_version,
+			language=NULLIF(COALESCE($3, user_settings.language), ''),
+			timezone=NULLIF(COALESCE($4, user_settings.timezone), ''),
+			theme=NULLIF(COALESCE($5, user_settings.theme), ''),
+			default_post_visibility=NULLIF(COALESCE($6, user_settings.default_post_visibility), ''),
+			email_frequency=NULLIF(COALESCE($7, user_settings.email_frequency), ''),
+			updated_at=CURRENT_TIMESTAMP
+		RETURNING ` + settingsColumns
+
+	return scanSettings(sr.db.QueryRow(
+		query,
+		params.UserID,
+		params.SchemaVersion,
+		params.Language,
+		params.Timezone,
+		params.Theme,
+		params.DefaultPostVisibility,
+		params.EmailFrequency,
+	))
+}
+
+func scanSettings(row rowScanner) (*repo.UserSettings, error) {
+	var (
+		result                                repo.UserSettings
+		language, timezone, theme             sql.NullString
+		defaultPostVisibility, emailFrequency sql.NullString
+	)
+
+	err := row.Scan(
+		&result.UserID,
+		&result.SchemaVersion,
+		&language,
+		&timezone,
+		&theme,
+		&defaultPostVisibility,
+		&emailFrequency,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Language = language.String
+	result.Timezone = timezone.String
+	result.Theme = theme.String
+	result.DefaultPostVisibility = defaultPostVisibility.String
+	result.EmailFrequency = emailFrequency.String
+
+	return &result, nil
+}
@@ -0,0 +1,38 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/SaidovZohid/medium_user_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSettings(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	_, err := dbManager.Settings().Get(user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	theme, language := "dark", "uz"
+	settings, err := dbManager.Settings().Update(&repo.UpdateSettingsParams{
+		UserID:        user.ID,
+		SchemaVersion: 1,
+		Theme:         &theme,
+		Language:      &language,
+	})
+	require.NoError(t, err)
+	require.Equal(t, theme, settings.Theme)
+	require.Equal(t, language, settings.Language)
+
+	reset := ""
+	settings, err = dbManager.Settings().Update(&repo.UpdateSettingsParams{
+		UserID:        user.ID,
+		SchemaVersion: 1,
+		Language:      &reset,
+	})
+	require.NoError(t, err)
+	require.Equal(t, theme, settings.Theme)
+	require.Empty(t, settings.Language)
+}
@@ -2,11 +2,15 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/SaidovZohid/medium_user_service/pkg/utils"
 	"github.com/SaidovZohid/medium_user_service/storage/repo"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type userRepo struct {
@@ -30,11 +34,22 @@ func (ur *userRepo) Create(user *repo.User) (*repo.User, error) {
 			password,
 			username,
 			profile_image_url,
-			type
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			type,
+			bio,
+			headline,
+			website,
+			social_links,
+			location,
+			pronouns
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at
 	`
-	err := ur.db.QueryRow(
+	socialLinks, err := marshalSocialLinks(user.SocialLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ur.db.QueryRow(
 		query,
 		user.FirstName,
 		user.LastName,
@@ -45,12 +60,21 @@ func (ur *userRepo) Create(user *repo.User) (*repo.User, error) {
 		utils.NullString(user.Username),
 		utils.NullString(user.ProfileImageUrl),
 		user.Type,
+		utils.NullString(user.Bio),
+		utils.NullString(user.Headline),
+		utils.NullString(user.Website),
+		socialLinks,
+		utils.NullString(user.Location),
+		utils.NullString(user.Pronouns),
 	).Scan(
 		&user.ID,
 		&user.CreatedAt,
 	)
 
 	if err != nil {
+		if isPqError(err, uniqueViolation) {
+			return nil, repo.ErrAlreadyExists
+		}
 		return nil, err
 	}
 
@@ -58,69 +82,42 @@ func (ur *userRepo) Create(user *repo.User) (*repo.User, error) {
 }
 
 func (ur *userRepo) Get(user_id int64) (*repo.User, error) {
-	var (
-		result                                         repo.User
-		phoneNumber, gender, Username, profileImageUrl sql.NullString
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND deleted_at IS NULL`
 
-	query := `
-		SELECT 
-			id,
-			first_name,
-			last_name,
-			phone_number,
-			email,
-			gender,
-			password,
-			username,
-			profile_image_url,
-			type,
-			created_at
-		FROM users WHERE id = $1
-	`
-	err := ur.db.QueryRow(
-		query,
-		user_id,
-	).Scan(
-		&result.ID,
-		&result.FirstName,
-		&result.LastName,
-		&phoneNumber,
-		&result.Email,
-		&gender,
-		&result.Password,
-		&Username,
-		&profileImageUrl,
-		&result.Type,
-		&result.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	result.PhoneNumber = phoneNumber.String
-	result.Gender = gender.String
-	result.Username = Username.String
-	result.ProfileImageUrl = profileImageUrl.String
-
-	return &result, nil
+	return scanUser(ur.db.QueryRow(query, user_id))
 }
 
 func (ur *userRepo) Update(user *repo.User) (*repo.User, error) {
+	var phoneVerifiedAt sql.NullTime
+
 	query := `
 		UPDATE users SET
 			first_name=$1,
 			last_name=$2,
+			phone_verified_at=CASE WHEN phone_number IS DISTINCT FROM $3 THEN NULL ELSE phone_verified_at END,
 			phone_number=$3,
 			gender=$4,
 			username=$5,
-			profile_image_url=$6
-		WHERE id=$7 
+			profile_image_url=$6,
+			bio=$7,
+			headline=$8,
+			website=$9,
+			social_links=$10,
+			location=$11,
+			pronouns=$12
+		WHERE id=$13 AND deleted_at IS NULL
 		RETURNING 
 			email,
 			type,
-			created_at
+			created_at,
+			phone_verified_at
 	`
-	err := ur.db.QueryRow(
+	socialLinks, err := marshalSocialLinks(user.SocialLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ur.db.QueryRow(
 		query,
 		user.FirstName,
 		user.LastName,
@@ -128,22 +125,32 @@ func (ur *userRepo) Update(user *repo.User) (*repo.User, error) {
 		utils.NullString(user.Gender),
 		utils.NullString(user.Username),
 		utils.NullString(user.ProfileImageUrl),
+		utils.NullString(user.Bio),
+		utils.NullString(user.Headline),
+		utils.NullString(user.Website),
+		socialLinks,
+		utils.NullString(user.Location),
+		utils.NullString(user.Pronouns),
 		user.ID,
 	).Scan(
 		&user.Email,
 		&user.Type,
 		&user.CreatedAt,
+		&phoneVerifiedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if phoneVerifiedAt.Valid {
+		user.PhoneVerifiedAt = &phoneVerifiedAt.Time
+	}
 
 	return user, nil
 }
 
 func (ur *userRepo) Delete(user_id int64) error {
 	query := `
-		DELETE FROM users WHERE id = $1
+		UPDATE users SET deleted_at=CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL
 	`
 	result, err := ur.db.Exec(
 		query,
@@ -164,75 +171,48 @@ func (ur *userRepo) GetAll(params *repo.GetAllUserParams) (*repo.GetAllUsersResu
 	result := repo.GetAllUsersResult{
 		Users: make([]*repo.User, 0),
 	}
-	var (
-		phoneNumber, gender, username, profileImageUrl sql.NullString
-	)
 
 	offset := (params.Page - 1) * params.Limit
 
 	limit := fmt.Sprintf(" LIMIT %d OFFSET %d", params.Limit, offset)
 
-	filter := ""
+	filter := " WHERE deleted_at IS NULL "
+	args := []interface{}{}
 
 	if params.Search != "" {
-		str := "%" + params.Search + "%"
-		filter = fmt.Sprintf(`
-			WHERE first_name ILIKE '%s' OR last_name ILIKE '%s' OR phone_number ILIKE '%s' OR email ILIKE '%s' OR Username ILIKE '%s'
-		`, str, str, str, str, str)
+		args = append(args, "%"+params.Search+"%")
+		if params.SearchPrivate {
+			filter += `
+				AND (first_name ILIKE $1 OR last_name ILIKE $1 OR phone_number ILIKE $1 OR email ILIKE $1 OR username ILIKE $1)
+			`
+		} else {
+			filter += `
+				AND (first_name ILIKE $1 OR last_name ILIKE $1 OR username ILIKE $1)
+			`
+		}
 	}
 
-	query := `
-		SELECT 
-			id,
-			first_name,
-			last_name,
-			phone_number,
-			email,
-			gender,
-			password,
-			username,
-			profile_image_url,
-			type,
-			created_at
-		FROM users
-	` + filter + `
+	query := `SELECT ` + userColumns + ` FROM users ` + filter + `
 		ORDER BY created_at DESC
 	` + limit
 
-	rows, err := ur.db.Query(query)
+	rows, err := ur.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var user repo.User
-		err := rows.Scan(
-			&user.ID,
-			&user.FirstName,
-			&user.LastName,
-			&phoneNumber,
-			&user.Email,
-			&gender,
-			&user.Password,
-			&username,
-			&profileImageUrl,
-			&user.Type,
-			&user.CreatedAt,
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, err
 		}
-		user.PhoneNumber = phoneNumber.String
-		user.Gender = gender.String
-		user.Username = username.String
-		user.ProfileImageUrl = profileImageUrl.String
-		result.Users = append(result.Users, &user)
+		result.Users = append(result.Users, user)
 	}
 
 	queryCount := "SELECT count(1) FROM users " + filter
 
-	if err = ur.db.QueryRow(queryCount).Scan(&result.Count); err != nil {
+	if err = ur.db.QueryRow(queryCount, args...).Scan(&result.Count); err != nil {
 		return nil, err
 	}
 
@@ -240,13 +220,228 @@ func (ur *userRepo) GetAll(params *repo.GetAllUserParams) (*repo.GetAllUsersResu
 }
 
 func (ur *userRepo) GetByEmail(user_email string) (*repo.User, error) {
-	var (
-		result                                         repo.User
-		phoneNumber, gender, username, profileImageUrl sql.NullString
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE lower(email) = lower($1) AND deleted_at IS NULL`
+
+	return scanUser(ur.db.QueryRow(query, user_email))
+}
+
+func (ur *userRepo) GetByPhoneNumber(phone_number string) (*repo.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+		WHERE phone_number = $1 AND phone_verified_at IS NOT NULL AND deleted_at IS NULL`
+
+	return scanUser(ur.db.QueryRow(query, phone_number))
+}
+
+func (ur *userRepo) GetByUsername(username string) (*repo.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = $1 AND deleted_at IS NULL`
+
+	return scanUser(ur.db.QueryRow(query, username))
+}
+
+func (ur *userRepo) UpdatePassword(req *repo.UpdatePassword) error {
+	query := `UPDATE users SET password=$1 WHERE id=$2 AND deleted_at IS NULL`
+	_, err := ur.db.Exec(query, req.Password, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateEmail returns repo.ErrAlreadyExists if another user has the email
+func (ur *userRepo) UpdateEmail(user_id int64, email string) error {
+	query := `UPDATE users SET email=$1 WHERE id=$2 AND deleted_at IS NULL`
+	result, err := ur.db.Exec(query, email, user_id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return repo.ErrAlreadyExists
+		}
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// VerifyPhoneNumber saves the phone number as verified, it returns
+// repo.ErrAlreadyExists if another user has the number
+func (ur *userRepo) VerifyPhoneNumber(user_id int64, phone_number string) error {
+	tx, err := ur.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET phone_number=$1, phone_verified_at=CURRENT_TIMESTAMP WHERE id=$2 AND deleted_at IS NULL`
+	result, err := tx.Exec(query, phone_number, user_id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return repo.ErrAlreadyExists
+		}
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	query = `UPDATE users SET phone_number=NULL WHERE phone_number=$1 AND id<>$2 AND phone_verified_at IS NULL`
+	_, err = tx.Exec(query, phone_number, user_id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateType returns repo.ErrRoleNotFound if there is no such role
+func (ur *userRepo) UpdateType(user_id int64, user_type string) error {
+	query := `UPDATE users SET type=$1 WHERE id=$2 AND deleted_at IS NULL`
+	result, err := ur.db.Exec(query, user_type, user_id)
+	if err != nil {
+		if isPqError(err, foreignKeyViolation) {
+			return repo.ErrRoleNotFound
+		}
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (ur *userRepo) UpdateAvatar(user_id int64, profile_image_url string, avatar_urls map[string]string) (map[string]string, error) {
+	urls, err := json.Marshal(avatar_urls)
+	if err != nil {
+		return nil, err
+	}
 
 	query := `
-		SELECT 
+		UPDATE users u SET
+			profile_image_url=$1,
+			avatar_urls=$2
+		FROM (SELECT id, avatar_urls FROM users WHERE id=$3 AND deleted_at IS NULL FOR UPDATE) old
+		WHERE u.id = old.id
+		RETURNING old.avatar_urls
+	`
+	var oldUrls []byte
+	err = ur.db.QueryRow(query, profile_image_url, urls, user_id).Scan(&oldUrls)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal(oldUrls, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (ur *userRepo) GetDeleted(email, phone_number string) (*repo.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+		WHERE (lower(email) = lower($1) OR phone_number = $2 AND phone_verified_at IS NOT NULL)
+			AND deleted_at IS NOT NULL AND purged_at IS NULL`
+
+	return scanUser(ur.db.QueryRow(query, email, phone_number))
+}
+
+func (ur *userRepo) Restore(user_id int64, deleted_after time.Time) error {
+	query := `
+		UPDATE users SET deleted_at=NULL
+		WHERE id=$1 AND deleted_at > $2 AND purged_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM account_deletions WHERE user_id=$1)
+	`
+	result, err := ur.db.Exec(query, user_id, deleted_after)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// purgedUserTables keep personal data that is removed together with the user
+var purgedUserTables = map[string][]string{
+	"refresh_tokens":           {"user_id"},
+	"sessions":                 {"user_id"},
+	"user_mfa":                 {"user_id"},
+	"mfa_recovery_codes":       {"user_id"},
+	"user_identities":          {"user_id"},
+	"personal_access_tokens":   {"user_id"},
+	"follows":                  {"follower_id", "following_id"},
+	"blocks":                   {"blocker_id", "blocked_id"},
+	"mutes":                    {"muter_id", "muted_id"},
+	"user_settings":            {"user_id"},
+	"notification_preferences": {"user_id"},
+}
+
+// Purge keeps the row of the user so that the id other services refer to
+// stays valid, but nothing in it identifies the person anymore
+func (ur *userRepo) Purge(user_id int64) (*repo.User, error) {
+	tx, err := ur.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + userColumns + ` FROM users
+		WHERE id = $1 AND deleted_at IS NOT NULL AND purged_at IS NULL
+		FOR UPDATE`
+	user, err := scanUser(tx.QueryRow(query, user_id))
+	if err != nil {
+		return nil, err
+	}
+
+	for table, columns := range purgedUserTables {
+		for _, column := range columns {
+			_, err := tx.Exec(`DELETE FROM `+table+` WHERE `+column+` = $1`, user_id)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE users SET
+			first_name='Deleted',
+			last_name='User',
+			email='deleted-' || id || '@deleted.invalid',
+			phone_number=NULL,
+			phone_verified_at=NULL,
+			gender=NULL,
+			password='',
+			username=NULL,
+			profile_image_url=NULL,
+			bio=NULL,
+			headline=NULL,
+			website=NULL,
+			social_links='{}',
+			location=NULL,
+			pronouns=NULL,
+			avatar_urls='{}',
+			purged_at=CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, tx.Commit()
+}
+
+// userColumns is the column list scanUser expects
+const userColumns = `
 			id,
 			first_name,
 			last_name,
@@ -257,13 +452,32 @@ func (ur *userRepo) GetByEmail(user_email string) (*repo.User, error) {
 			username,
 			profile_image_url,
 			type,
-			created_at
-		FROM users WHERE email = $1
-	`
-	err := ur.db.QueryRow(
-		query,
-		user_email,
-	).Scan(
+			created_at,
+			phone_verified_at,
+			bio,
+			headline,
+			website,
+			social_links,
+			location,
+			pronouns,
+			avatar_urls,
+			deleted_at
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*repo.User, error) {
+	var (
+		result                                         repo.User
+		phoneNumber, gender, username, profileImageUrl sql.NullString
+		phoneVerifiedAt, deletedAt                     sql.NullTime
+		bio, headline, website, location, pronouns     sql.NullString
+		socialLinks, avatarUrls                        []byte
+	)
+
+	err := row.Scan(
 		&result.ID,
 		&result.FirstName,
 		&result.LastName,
@@ -275,25 +489,47 @@ func (ur *userRepo) GetByEmail(user_email string) (*repo.User, error) {
 		&profileImageUrl,
 		&result.Type,
 		&result.CreatedAt,
+		&phoneVerifiedAt,
+		&bio,
+		&headline,
+		&website,
+		&socialLinks,
+		&location,
+		&pronouns,
+		&avatarUrls,
+		&deletedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
-
+	if err := json.Unmarshal(socialLinks, &result.SocialLinks); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(avatarUrls, &result.AvatarUrls); err != nil {
+		return nil, err
+	}
+	result.Bio = bio.String
+	result.Headline = headline.String
+	result.Website = website.String
+	result.Location = location.String
+	result.Pronouns = pronouns.String
 	result.PhoneNumber = phoneNumber.String
 	result.Gender = gender.String
 	result.Username = username.String
 	result.ProfileImageUrl = profileImageUrl.String
+	if phoneVerifiedAt.Valid {
+		result.PhoneVerifiedAt = &phoneVerifiedAt.Time
+	}
+	if deletedAt.Valid {
+		result.DeletedAt = &deletedAt.Time
+	}
 
 	return &result, nil
 }
 
-func (ur *userRepo) UpdatePassword(req *repo.UpdatePassword) error {
-	query := `UPDATE users SET password=$1 WHERE id=$2`
-	_, err := ur.db.Exec(query, req.Password, req.UserID)
-	if err != nil {
-		return err
+func marshalSocialLinks(links map[string]string) ([]byte, error) {
+	if links == nil {
+		links = map[string]string{}
 	}
-
-	return nil
+	return json.Marshal(links)
 }
@@ -1,7 +1,11 @@
 package postgres_test
 
 import (
+	"database/sql"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/SaidovZohid/medium_user_service/pkg/utils"
 	"github.com/SaidovZohid/medium_user_service/storage/repo"
@@ -73,3 +77,135 @@ func TestGetAll(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, user)
 }
+
+func TestGetAllSearchesEmailOnlyWhenPrivate(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	users, err := dbManager.User().GetAll(&repo.GetAllUserParams{
+		Limit:  10,
+		Page:   1,
+		Search: user.Email,
+	})
+	require.NoError(t, err)
+	require.Empty(t, users.Users)
+
+	users, err = dbManager.User().GetAll(&repo.GetAllUserParams{
+		Limit:         10,
+		Page:          1,
+		Search:        user.Email,
+		SearchPrivate: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, users.Users, 1)
+	require.Equal(t, int32(1), users.Count)
+}
+
+func TestUpdateEmail(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+	other := createUser(t)
+	defer deleteUser(t, other.ID)
+
+	email := faker.Email()
+	err := dbManager.User().UpdateEmail(user.ID, email)
+	require.NoError(t, err)
+
+	u, err := dbManager.User().Get(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, email, u.Email)
+
+	err = dbManager.User().UpdateEmail(other.ID, email)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+}
+
+func TestGetUserByUsername(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	user.Username = faker.Username()
+	user.Bio = "Writes about Go"
+	user.SocialLinks = map[string]string{"github": "https://github.com/" + user.Username}
+	_, err := dbManager.User().Update(user)
+	require.NoError(t, err)
+
+	u, err := dbManager.User().GetByUsername(user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, u.ID)
+	require.Equal(t, user.Bio, u.Bio)
+	require.Equal(t, user.SocialLinks, u.SocialLinks)
+}
+
+func TestRestoreUser(t *testing.T) {
+	user := createUser(t)
+	deleteUser(t, user.ID)
+
+	_, err := dbManager.User().Get(user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	u, err := dbManager.User().GetDeleted(user.Email, "")
+	require.NoError(t, err)
+	require.Equal(t, user.ID, u.ID)
+	require.NotNil(t, u.DeletedAt)
+
+	err = dbManager.User().Restore(user.ID, time.Now())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = dbManager.User().Restore(user.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	u, err = dbManager.User().Get(user.ID)
+	require.NoError(t, err)
+	require.Nil(t, u.DeletedAt)
+
+	deleteUser(t, user.ID)
+}
+
+func TestUnverifiedPhoneNumbersAreNotUnique(t *testing.T) {
+	owner := createUser(t)
+	defer deleteUser(t, owner.ID)
+	squatter := createUser(t)
+	defer deleteUser(t, squatter.ID)
+
+	phoneNumber := "+99890" + strconv.FormatInt(time.Now().UnixNano()%10000000, 10)
+	for _, user := range []*repo.User{squatter, owner} {
+		user.PhoneNumber = phoneNumber
+		_, err := dbManager.User().Update(user)
+		require.NoError(t, err)
+	}
+
+	_, err := dbManager.User().GetByPhoneNumber(phoneNumber)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = dbManager.User().VerifyPhoneNumber(owner.ID, phoneNumber)
+	require.NoError(t, err)
+
+	u, err := dbManager.User().GetByPhoneNumber(phoneNumber)
+	require.NoError(t, err)
+	require.Equal(t, owner.ID, u.ID)
+
+	u, err = dbManager.User().Get(squatter.ID)
+	require.NoError(t, err)
+	require.Empty(t, u.PhoneNumber)
+
+	err = dbManager.User().VerifyPhoneNumber(squatter.ID, phoneNumber)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+}
+
+func TestGetUserByEmailIgnoresCase(t *testing.T) {
+	user := createUser(t)
+	defer deleteUser(t, user.ID)
+
+	u, err := dbManager.User().GetByEmail(strings.ToUpper(user.Email))
+	require.NoError(t, err)
+	require.Equal(t, user.ID, u.ID)
+
+	_, err = dbManager.User().Create(&repo.User{
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     strings.ToUpper(user.Email),
+		Password:  user.Password,
+		Type:      "user",
+	})
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+}
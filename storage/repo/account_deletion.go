@@ -0,0 +1,50 @@
+package repo
+
+import "time"
+
+const (
+	DeletionStepPending = "pending"
+	DeletionStepDone    = "done"
+	// DeletionStepSkipped steps could not be done and were left as they are
+	DeletionStepSkipped = "skipped"
+)
+
+// AccountDeletion is the progress of erasing a deleted account, its steps
+// run in order and each of them can be retried
+type AccountDeletion struct {
+	UserID      int64
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	Steps       []*AccountDeletionStep
+}
+
+type AccountDeletionStep struct {
+	Name      string
+	Status    string
+	Attempts  int32
+	LastError string
+	UpdatedAt time.Time
+}
+
+type AccountDeletionStorageI interface {
+	// Create deletes the user and starts the deletion of the account in one
+	// transaction. It returns sql.ErrNoRows if there is no such user that is
+	// not purged and ErrAlreadyExists if the account is already being deleted.
+	Create(user_id int64, steps []string) error
+	// CreateForDeletedBefore starts the deletion of every account deleted
+	// before deleted_before and returns how many were started
+	CreateForDeletedBefore(deleted_before time.Time, steps []string) (int64, error)
+	Get(user_id int64) (*AccountDeletion, error)
+	// GetPending claims up to limit deletions that are due, the longest
+	// waiting first. Claimed deletions are not due again for lease, so
+	// another instance running at the same time skips them.
+	GetPending(limit int32, lease time.Duration) ([]*AccountDeletion, error)
+	// CompleteStep marks the step done, the deletion is completed once no
+	// step is pending
+	CompleteStep(user_id int64, step string) error
+	// SkipStep marks the step skipped with the reason, the deletion is
+	// completed once no step is pending
+	SkipStep(user_id int64, step, reason string) error
+	// FailStep records the error and makes the deletion due again at retry_at
+	FailStep(user_id int64, step, last_error string, retry_at time.Time) error
+}
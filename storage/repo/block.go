@@ -0,0 +1,23 @@
+package repo
+
+import "errors"
+
+var ErrBlocked = errors.New("user is blocked")
+
+type GetBlocksParams struct {
+	UserID int64
+	Limit  int32
+	Page   int32
+}
+
+type BlockStorageI interface {
+	// Block removes the follows between the users in both directions
+	Block(blocker_id, blocked_id int64) error
+	Unblock(blocker_id, blocked_id int64) error
+	ListBlocked(params *GetBlocksParams) (*GetAllUsersResult, error)
+	// IsBlocked reports whether either of the users blocked the other
+	IsBlocked(a, b int64) (bool, error)
+	Mute(muter_id, muted_id int64) error
+	Unmute(muter_id, muted_id int64) error
+	ListMuted(params *GetBlocksParams) (*GetAllUsersResult, error)
+}
@@ -0,0 +1,24 @@
+package repo
+
+type FollowCounts struct {
+	Followers int64
+	Following int64
+}
+
+type GetFollowsParams struct {
+	UserID int64
+	Limit  int32
+	Page   int32
+}
+
+type FollowStorageI interface {
+	// Follow returns ErrAlreadyExists if the user is followed already and
+	// ErrBlocked if either of the users blocked the other
+	Follow(follower_id, following_id int64) error
+	Unfollow(follower_id, following_id int64) error
+	ListFollowers(params *GetFollowsParams) (*GetAllUsersResult, error)
+	ListFollowing(params *GetFollowsParams) (*GetAllUsersResult, error)
+	IsFollowing(follower_id, following_id int64) (bool, error)
+	// GetCounts returns counts for every given user, zero counts included
+	GetCounts(user_ids ...int64) (map[int64]*FollowCounts, error)
+}
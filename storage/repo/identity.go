@@ -0,0 +1,18 @@
+package repo
+
+import "time"
+
+type Identity struct {
+	ID        int64
+	UserID    int64
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+type IdentityStorageI interface {
+	// Create returns ErrAlreadyExists if the identity is already linked
+	Create(i *Identity) (*Identity, error)
+	Get(provider, subject string) (*Identity, error)
+}
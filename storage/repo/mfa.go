@@ -0,0 +1,26 @@
+package repo
+
+import "time"
+
+type MFA struct {
+	UserID    int64
+	Secret    string
+	EnabledAt *time.Time
+	CreatedAt time.Time
+}
+
+type RecoveryCode struct {
+	ID       int64
+	UserID   int64
+	CodeHash string
+}
+
+type MFAStorageI interface {
+	SaveSecret(user_id int64, secret string) error
+	Get(user_id int64) (*MFA, error)
+	Enable(user_id int64) error
+	Delete(user_id int64) error
+	ReplaceRecoveryCodes(user_id int64, code_hashes []string) error
+	GetRecoveryCodes(user_id int64) ([]*RecoveryCode, error)
+	UseRecoveryCode(id int64) error
+}
@@ -0,0 +1,17 @@
+package repo
+
+import "time"
+
+type NotificationPreference struct {
+	UserID    int64
+	Category  string
+	Channel   string
+	Enabled   bool
+	UpdatedAt time.Time
+}
+
+type NotificationPreferenceStorageI interface {
+	// GetAll returns only the preferences the user has changed
+	GetAll(user_id int64) ([]*NotificationPreference, error)
+	Set(preferences []*NotificationPreference) error
+}
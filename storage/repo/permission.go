@@ -1,12 +1,18 @@
 package repo
 
 type Permission struct {
+	ID       int64
 	UserType string
 	Resource string
 	Action   string
+	// Scope is "any" or "own", see pkg/policy
+	Scope string
 }
 
 type PermissionStorageI interface {
 	CheckPermission(*Permission) (bool, error)
+	Create(p *Permission) (*Permission, error)
+	Delete(permission_id int64) error
+	// GetAll returns the grants of user_type, or of all roles if it is empty
+	GetAll(user_type string) ([]*Permission, error)
 }
-
@@ -0,0 +1,31 @@
+package repo
+
+import "time"
+
+type TokenScope struct {
+	Resource string
+	Action   string
+}
+
+type PersonalAccessToken struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	TokenHash  string
+	Prefix     string
+	Scopes     []*TokenScope
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+type PersonalAccessTokenStorageI interface {
+	Create(t *PersonalAccessToken) (*PersonalAccessToken, error)
+	GetByHash(token_hash string) (*PersonalAccessToken, error)
+	GetAllByUser(user_id int64) ([]*PersonalAccessToken, error)
+	// UpdateLastUsed sets the last used time unless it was set within the
+	// last minute, so busy tokens do not write on every request
+	UpdateLastUsed(id int64) error
+	Revoke(user_id, id int64) error
+}
@@ -0,0 +1,22 @@
+package repo
+
+import "time"
+
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+type RefreshTokenStorageI interface {
+	Create(t *RefreshToken) (*RefreshToken, error)
+	GetByHash(token_hash string) (*RefreshToken, error)
+	Rotate(id int64) error
+	RevokeFamily(family_id string) error
+	RevokeAllByUser(user_id int64) error
+}
@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+	ErrRoleInUse    = errors.New("role is assigned to users")
+)
+
+type Role struct {
+	ID          int64
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+type RoleStorageI interface {
+	Create(r *Role) (*Role, error)
+	Get(role_id int64) (*Role, error)
+	GetAll() ([]*Role, error)
+	Update(r *Role) (*Role, error)
+	Delete(role_id int64) error
+}
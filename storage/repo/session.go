@@ -0,0 +1,23 @@
+package repo
+
+import "time"
+
+type Session struct {
+	ID         string
+	UserID     int64
+	TokenID    string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+}
+
+type SessionStorageI interface {
+	Create(s *Session) (*Session, error)
+	Get(session_id string) (*Session, error)
+	UpdateToken(session_id, token_id string) error
+	GetAllByUser(user_id int64) ([]*Session, error)
+	Revoke(session_id string) error
+	RevokeAllByUser(user_id int64) error
+}
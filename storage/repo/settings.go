@@ -0,0 +1,34 @@
+package repo
+
+import "time"
+
+// UserSettings keeps the preferences of a user, an empty field is not set
+// and the default applies
+type UserSettings struct {
+	UserID                int64
+	SchemaVersion         int32
+	Language              string
+	Timezone              string
+	Theme                 string
+	DefaultPostVisibility string
+	EmailFrequency        string
+	UpdatedAt             time.Time
+}
+
+// UpdateSettingsParams changes the fields that are not nil, an empty value
+// unsets the field
+type UpdateSettingsParams struct {
+	UserID                int64
+	SchemaVersion         int32
+	Language              *string
+	Timezone              *string
+	Theme                 *string
+	DefaultPostVisibility *string
+	EmailFrequency        *string
+}
+
+type SettingsStorageI interface {
+	// Get returns sql.ErrNoRows if the user has never changed the settings
+	Get(user_id int64) (*UserSettings, error)
+	Update(params *UpdateSettingsParams) (*UserSettings, error)
+}
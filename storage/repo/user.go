@@ -1,6 +1,11 @@
 package repo
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var ErrAlreadyExists = errors.New("already exists")
 
 const (
 	UserTypeSuperadmin = "superadmin"
@@ -19,16 +24,53 @@ type User struct {
 	ProfileImageUrl string
 	Type            string
 	CreatedAt       time.Time
+	PhoneVerifiedAt *time.Time
+	Bio             string
+	Headline        string
+	Website         string
+	// SocialLinks maps a network such as twitter or github to the profile url
+	SocialLinks map[string]string
+	Location    string
+	Pronouns    string
+	// AvatarUrls maps the size of an uploaded avatar to its url
+	AvatarUrls map[string]string
+	DeletedAt  *time.Time
 }
 
 type UserStorageI interface {
+	// Create returns ErrAlreadyExists if the email or the username is taken,
+	// by a deleted user too
 	Create(u *User) (*User, error)
 	UpdatePassword(u *UpdatePassword) error
+	UpdateEmail(user_id int64, email string) error
 	Get(user_id int64) (*User, error)
 	Update(u *User) (*User, error)
+	// Delete marks the user as deleted, every other method except the
+	// deleted ones below ignores deleted users
 	Delete(user_id int64) error
 	GetAll(params *GetAllUserParams) (*GetAllUsersResult, error)
+	// GetByEmail ignores the case of the email
 	GetByEmail(user_email string) (*User, error)
+	// GetByPhoneNumber finds the user who verified the phone number, only
+	// verified phone numbers are unique
+	GetByPhoneNumber(phone_number string) (*User, error)
+	GetByUsername(username string) (*User, error)
+	// VerifyPhoneNumber gives the phone number to the user and removes it
+	// from the users who saved it without verifying it
+	VerifyPhoneNumber(user_id int64, phone_number string) error
+	UpdateType(user_id int64, user_type string) error
+	// UpdateAvatar sets the profile image and its variants at once and
+	// returns the variants it replaced
+	UpdateAvatar(user_id int64, profile_image_url string, avatar_urls map[string]string) (map[string]string, error)
+	// GetDeleted finds a deleted user that is not purged yet by email or
+	// verified phone number
+	GetDeleted(email, phone_number string) (*User, error)
+	// Restore undoes Delete if the user was deleted after deleted_after and
+	// the erasing of the account has not started
+	Restore(user_id int64, deleted_after time.Time) error
+	// Purge erases the personal data of a deleted user and returns the user
+	// as it was before
+	Purge(user_id int64) (*User, error)
 }
 
 type UpdatePassword struct {
@@ -40,6 +82,9 @@ type GetAllUserParams struct {
 	Limit  int32
 	Page   int32
 	Search string
+	// SearchPrivate also matches the search against emails and phone
+	// numbers, only admins may search them
+	SearchPrivate bool
 }
 
 type GetAllUsersResult struct {
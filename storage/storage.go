@@ -9,17 +9,50 @@ import (
 type StorageI interface {
 	User() repo.UserStorageI
 	Permission() repo.PermissionStorageI
+	Role() repo.RoleStorageI
+	RefreshToken() repo.RefreshTokenStorageI
+	Session() repo.SessionStorageI
+	MFA() repo.MFAStorageI
+	Identity() repo.IdentityStorageI
+	PersonalAccessToken() repo.PersonalAccessTokenStorageI
+	Follow() repo.FollowStorageI
+	Block() repo.BlockStorageI
+	AccountDeletion() repo.AccountDeletionStorageI
+	Settings() repo.SettingsStorageI
+	NotificationPreference() repo.NotificationPreferenceStorageI
 }
 
 type StoragePg struct {
-	userRepo     repo.UserStorageI
-	permissionRepo     repo.PermissionStorageI
+	userRepo         repo.UserStorageI
+	permissionRepo   repo.PermissionStorageI
+	roleRepo         repo.RoleStorageI
+	refreshTokenRepo repo.RefreshTokenStorageI
+	sessionRepo      repo.SessionStorageI
+	mfaRepo          repo.MFAStorageI
+	identityRepo     repo.IdentityStorageI
+	patRepo          repo.PersonalAccessTokenStorageI
+	followRepo       repo.FollowStorageI
+	blockRepo        repo.BlockStorageI
+	deletionRepo     repo.AccountDeletionStorageI
+	settingsRepo     repo.SettingsStorageI
+	notificationRepo repo.NotificationPreferenceStorageI
 }
 
 func NewStoragePg(db *sqlx.DB) StorageI {
 	return &StoragePg{
-		userRepo:     postgres.NewUser(db),
-		permissionRepo:     postgres.NewPermission(db),
+		userRepo:         postgres.NewUser(db),
+		permissionRepo:   postgres.NewPermission(db),
+		roleRepo:         postgres.NewRole(db),
+		refreshTokenRepo: postgres.NewRefreshToken(db),
+		sessionRepo:      postgres.NewSession(db),
+		mfaRepo:          postgres.NewMFA(db),
+		identityRepo:     postgres.NewIdentity(db),
+		patRepo:          postgres.NewPersonalAccessToken(db),
+		followRepo:       postgres.NewFollow(db),
+		blockRepo:        postgres.NewBlock(db),
+		deletionRepo:     postgres.NewAccountDeletion(db),
+		settingsRepo:     postgres.NewSettings(db),
+		notificationRepo: postgres.NewNotificationPreference(db),
 	}
 }
 
@@ -29,4 +62,48 @@ func (s *StoragePg) User() repo.UserStorageI {
 
 func (s *StoragePg) Permission() repo.PermissionStorageI {
 	return s.permissionRepo
-}
\ No newline at end of file
+}
+
+func (s *StoragePg) Role() repo.RoleStorageI {
+	return s.roleRepo
+}
+
+func (s *StoragePg) RefreshToken() repo.RefreshTokenStorageI {
+	return s.refreshTokenRepo
+}
+
+func (s *StoragePg) Session() repo.SessionStorageI {
+	return s.sessionRepo
+}
+
+func (s *StoragePg) MFA() repo.MFAStorageI {
+	return s.mfaRepo
+}
+
+func (s *StoragePg) Identity() repo.IdentityStorageI {
+	return s.identityRepo
+}
+
+func (s *StoragePg) PersonalAccessToken() repo.PersonalAccessTokenStorageI {
+	return s.patRepo
+}
+
+func (s *StoragePg) Follow() repo.FollowStorageI {
+	return s.followRepo
+}
+
+func (s *StoragePg) Block() repo.BlockStorageI {
+	return s.blockRepo
+}
+
+func (s *StoragePg) AccountDeletion() repo.AccountDeletionStorageI {
+	return s.deletionRepo
+}
+
+func (s *StoragePg) Settings() repo.SettingsStorageI {
+	return s.settingsRepo
+}
+
+func (s *StoragePg) NotificationPreference() repo.NotificationPreferenceStorageI {
+	return s.notificationRepo
+}